@@ -7,49 +7,80 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 )
 
 // BulkDataClient contains methods for interacting with bulk data such
 // as dumps of the Scryfall database.
 type BulkDataClient struct {
 	client *http.Client
+	clock  Clock
 }
 
+// ErrUnrecognizedBulkDataType was returned when listing sources included an
+// unknown type.
+//
+// Deprecated: unknown types are now available from BulkDataSources.Other,
+// and this error is no longer returned.
 var ErrUnrecognizedBulkDataType = errors.New("unrecognized bulk data type")
 
-func getBulkDataSources(data []BulkDataSource) (*BulkDataSources, error) {
+const (
+	// BulkDataTypeOracleCards contains one card per Oracle ID.
+	BulkDataTypeOracleCards = "oracle_cards"
+
+	// BulkDataTypeUniqueArtwork contains one card per unique illustration.
+	BulkDataTypeUniqueArtwork = "unique_artwork"
+
+	// BulkDataTypeDefaultCards contains every card in English, or in
+	// another language if English is not available.
+	BulkDataTypeDefaultCards = "default_cards"
+
+	// BulkDataTypeAllCards contains every card in every language.
+	BulkDataTypeAllCards = "all_cards"
+
+	// BulkDataTypeRulings contains all of the rulings.
+	BulkDataTypeRulings = "rulings"
+)
+
+func getBulkDataSources(data []BulkDataSource) *BulkDataSources {
 	var ret BulkDataSources
 
 	for _, item := range data {
 		dataCopy := item
 
 		switch item.Type {
-		case "oracle_cards":
+		case BulkDataTypeOracleCards:
 			ret.OracleCards = &dataCopy
-		case "unique_artwork":
+		case BulkDataTypeUniqueArtwork:
 			ret.UniqueArtwork = &dataCopy
-		case "default_cards":
+		case BulkDataTypeDefaultCards:
 			ret.DefaultCards = &dataCopy
-		case "all_cards":
+		case BulkDataTypeAllCards:
 			ret.AllCards = &dataCopy
-		case "rulings":
+		case BulkDataTypeRulings:
 			ret.Rulings = &dataCopy
 		default:
-			return nil, fmt.Errorf("%w: \"%s\"", ErrUnrecognizedBulkDataType, item.Type)
+			if ret.Other == nil {
+				ret.Other = map[string]*BulkDataSource{}
+			}
+
+			ret.Other[item.Type] = &dataCopy
 		}
 	}
 
-	return &ret, nil
+	return &ret
 }
 
 // ListSources lists all available bulk data sources.
+// Sources of types this package does not know about are available
+// from BulkDataSources.Other.
 func (b *BulkDataClient) ListSources(ctx context.Context) (*BulkDataSources, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.scryfall.com/bulk-data", nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP request to get bulk data list: %w", err)
 	}
 
-	resp, err := b.client.Do(req)
+	resp, err := doRawRequest(b.client, b.clock, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to perform HTTP request to get bulk data list: %w", err)
 	}
@@ -67,7 +98,39 @@ func (b *BulkDataClient) ListSources(ctx context.Context) (*BulkDataSources, err
 		return nil, fmt.Errorf("failed to unmarshal bulk data list response: %w", err)
 	}
 
-	return getBulkDataSources(dataList.Data)
+	return getBulkDataSources(dataList.Data), nil
+}
+
+// ByID fetches a single bulk data source by its UUID.
+func (b *BulkDataClient) ByID(ctx context.Context, id string) (*BulkDataSource, error) {
+	return b.get(ctx, id)
+}
+
+// ByType fetches a single bulk data source by its type, e.g. BulkDataTypeDefaultCards.
+// Types this package has no constant for can also be requested.
+func (b *BulkDataClient) ByType(ctx context.Context, bulkType string) (*BulkDataSource, error) {
+	return b.get(ctx, bulkType)
+}
+
+// get fetches a single bulk data source.  Scryfall accepts either
+// an ID or a type in the same position of the path.
+func (b *BulkDataClient) get(ctx context.Context, idOrType string) (*BulkDataSource, error) {
+	// https://scryfall.com/docs/api/bulk-data/id
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodGet, "https://api.scryfall.com/bulk-data/"+url.PathEscape(idOrType), nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	var source BulkDataSource
+
+	err = doRequest(b.client, b.clock, req, &source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to perform HTTP request: %w", err)
+	}
+
+	return &source, nil
 }
 
 type BulkDataSource struct {
@@ -109,6 +172,29 @@ type BulkDataSources struct {
 	// all cards in all languages
 	AllCards *BulkDataSource
 	Rulings  *BulkDataSource
+
+	// Other contains sources whose types do not have a dedicated
+	// field, keyed by type.  It is nil if there are none.
+	Other map[string]*BulkDataSource
+}
+
+// Get returns the source of the given type, or nil if there is none.
+// It works for both the types with dedicated fields and those in Other.
+func (b *BulkDataSources) Get(bulkType string) *BulkDataSource {
+	switch bulkType {
+	case BulkDataTypeOracleCards:
+		return b.OracleCards
+	case BulkDataTypeUniqueArtwork:
+		return b.UniqueArtwork
+	case BulkDataTypeDefaultCards:
+		return b.DefaultCards
+	case BulkDataTypeAllCards:
+		return b.AllCards
+	case BulkDataTypeRulings:
+		return b.Rulings
+	default:
+		return b.Other[bulkType]
+	}
 }
 
 // ErrFirstTokenNotDelim is returned when the first token in the JSON stream is not a delimeter.
@@ -120,6 +206,74 @@ var ErrFirstTokenNotOpenBracket = errors.New("first token is not an open bracket
 // NewBulkReader creates a BulkReader[T] from a given io.Reader.
 // The BulkReader will not close the underlying reader.
 func NewBulkReader[T any](src io.Reader) (*BulkReader[T], error) {
+	return NewBulkReaderWithOptions[T](src, BulkReaderOptions{})
+}
+
+// NewBulkReaderWithOptions creates a BulkReader[T] from a given io.Reader,
+// configured by opts.  The BulkReader will not close the underlying reader.
+func NewBulkReaderWithOptions[T any](src io.Reader, opts BulkReaderOptions) (*BulkReader[T], error) {
+	decoder, err := openBulkArray(src)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BulkReader[T]{
+		decoder: decoder,
+		opts:    opts,
+	}, nil
+}
+
+// BulkReaderOptions configures a BulkReader.
+type BulkReaderOptions struct {
+	// SkipInvalid makes Next skip elements that are valid JSON but
+	// cannot be unmarshaled into T, e.g. a card with an unknown Legality,
+	// instead of returning an error.
+	//
+	// Skipped elements are passed to OnError if it is set, and are
+	// otherwise collected and available from Skipped().
+	SkipInvalid bool
+
+	// OnError is called with each element skipped because of SkipInvalid.
+	// Optional.
+	OnError func(*BulkElementError)
+}
+
+// BulkElementError is returned when a single element of a bulk JSON list
+// could not be unmarshaled.  Reading can continue after this error.
+type BulkElementError struct {
+	// Index is the zero-based position of the element in the list.
+	Index int
+
+	// Raw is the element's JSON, exactly as it appeared in the source.
+	Raw json.RawMessage
+
+	// Err is the error from unmarshaling the element.
+	Err error
+}
+
+func (b *BulkElementError) Error() string {
+	return fmt.Sprintf("failed to parse JSON for bulk element %d: %v", b.Index, b.Err)
+}
+
+// Unwrap returns the underlying unmarshaling error.
+func (b *BulkElementError) Unwrap() error {
+	return b.Err
+}
+
+// report hands a skipped element to OnError, or collects it if there is no callback.
+func (b BulkReaderOptions) report(elemErr *BulkElementError, skipped *[]*BulkElementError) {
+	if b.OnError != nil {
+		b.OnError(elemErr)
+
+		return
+	}
+
+	*skipped = append(*skipped, elemErr)
+}
+
+// openBulkArray creates a json.Decoder for src and consumes the opening
+// bracket of the top-level JSON array.
+func openBulkArray(src io.Reader) (*json.Decoder, error) {
 	decoder := json.NewDecoder(src)
 
 	firstToken, err := decoder.Token()
@@ -136,29 +290,69 @@ func NewBulkReader[T any](src io.Reader) (*BulkReader[T], error) {
 		return nil, ErrFirstTokenNotOpenBracket
 	}
 
-	return &BulkReader[T]{
-		decoder: decoder,
-	}, nil
+	return decoder, nil
 }
 
 // BulkReader[T] is a generic reader for lists of data encoded as JSON.
 type BulkReader[T any] struct {
 	//nolint:structcheck
 	decoder *json.Decoder
+	opts    BulkReaderOptions
+	index   int
+	skipped []*BulkElementError
 }
 
 // Next returns the next item in the reader.  It returns io.EOF if there are no more items.
 // It returns a non-EOF error if the next item could not be parsed.
+//
+// If SkipInvalid is set, elements that cannot be unmarshaled into T are
+// skipped, and an error is only returned if the JSON stream itself is malformed.
 func (b *BulkReader[T]) Next() (*T, error) {
-	var ret T
+	if !b.opts.SkipInvalid {
+		var ret T
+
+		if !b.decoder.More() {
+			return nil, io.EOF
+		}
+
+		b.index++
 
-	if !b.decoder.More() {
-		return nil, io.EOF
+		if err := b.decoder.Decode(&ret); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON for bulk: %w", err)
+		}
+
+		return &ret, nil
 	}
 
-	if err := b.decoder.Decode(&ret); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON for bulk: %w", err)
+	for b.decoder.More() {
+		var raw json.RawMessage
+
+		// Decoding into a RawMessage only fails on malformed JSON,
+		// so the decoder is never left in the middle of an element.
+		if err := b.decoder.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON for bulk: %w", err)
+		}
+
+		index := b.index
+		b.index++
+
+		var ret T
+
+		if err := json.Unmarshal(raw, &ret); err != nil {
+			b.opts.report(&BulkElementError{Index: index, Raw: raw, Err: err}, &b.skipped)
+
+			continue
+		}
+
+		return &ret, nil
 	}
 
-	return &ret, nil
+	return nil, io.EOF
+}
+
+// Skipped returns the elements skipped so far because of SkipInvalid.
+// It is always empty if OnError is set, as skipped elements are passed
+// to the callback instead.
+func (b *BulkReader[T]) Skipped() []*BulkElementError {
+	return b.skipped
 }
@@ -42,6 +42,28 @@ func TestBulkData(t *testing.T) {
 		t.Error("expected non-nil value for UniqueArtwork")
 	}
 
+	t.Run("ByType", func(t *testing.T) {
+		t.Parallel()
+
+		source, err := client.BulkData.ByType(context.Background(), gofall.BulkDataTypeRulings)
+		if err != nil {
+			t.Fatalf("failed to get rulings source by type: %v", err)
+		}
+
+		if source.ID != sources.Rulings.ID {
+			t.Errorf("source has ID %q, expected %q", source.ID, sources.Rulings.ID)
+		}
+
+		byID, err := client.BulkData.ByID(context.Background(), source.ID)
+		if err != nil {
+			t.Fatalf("failed to get rulings source by ID: %v", err)
+		}
+
+		if byID.Type != gofall.BulkDataTypeRulings {
+			t.Errorf("source has type %q, expected %q", byID.Type, gofall.BulkDataTypeRulings)
+		}
+	})
+
 	t.Run("DefaultCards", func(t *testing.T) {
 		t.Parallel()
 
@@ -221,3 +243,118 @@ func TestBulkReader_Rulings(t *testing.T) {
 		t.Errorf("expected 10 rulings, got %d", numRulings)
 	}
 }
+
+func TestBulkReader_SkipInvalid(t *testing.T) {
+	t.Parallel()
+
+	testData := `[{"oracle_id":"a"},{"oracle_id":"b","legalities":{"standard":"bogus"}},{"oracle_id":"c"}]`
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+
+		bulkReader, err := gofall.NewBulkReader[gofall.Card](bytes.NewBufferString(testData))
+		if err != nil {
+			t.Fatalf("failed to create new bulk reader: %v", err)
+		}
+
+		if _, err := bulkReader.Next(); err != nil {
+			t.Fatalf("failed to read first card: %v", err)
+		}
+
+		if _, err := bulkReader.Next(); !errors.Is(err, gofall.ErrUnknownLegality) {
+			t.Errorf("expected ErrUnknownLegality, got %v", err)
+		}
+	})
+
+	t.Run("collected", func(t *testing.T) {
+		t.Parallel()
+
+		bulkReader, err := gofall.NewBulkReaderWithOptions[gofall.Card](
+			bytes.NewBufferString(testData),
+			gofall.BulkReaderOptions{SkipInvalid: true},
+		)
+		if err != nil {
+			t.Fatalf("failed to create new bulk reader: %v", err)
+		}
+
+		var oracleIDs []string
+
+		for {
+			card, err := bulkReader.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			if err != nil {
+				t.Fatalf("got unexpected error while reading next card: %v", err)
+			}
+
+			oracleIDs = append(oracleIDs, card.OracleID)
+		}
+
+		if len(oracleIDs) != 2 || oracleIDs[0] != "a" || oracleIDs[1] != "c" {
+			t.Errorf("unexpected cards read: %v", oracleIDs)
+		}
+
+		skipped := bulkReader.Skipped()
+		if len(skipped) != 1 {
+			t.Fatalf("expected 1 skipped element, got %d", len(skipped))
+		}
+
+		if skipped[0].Index != 1 {
+			t.Errorf("expected skipped index 1, got %d", skipped[0].Index)
+		}
+
+		if !errors.Is(skipped[0], gofall.ErrUnknownLegality) {
+			t.Errorf("expected ErrUnknownLegality, got %v", skipped[0].Err)
+		}
+
+		if !bytes.Contains(skipped[0].Raw, []byte(`"bogus"`)) {
+			t.Errorf("unexpected raw element: %s", skipped[0].Raw)
+		}
+	})
+
+	t.Run("callback", func(t *testing.T) {
+		t.Parallel()
+
+		var reported []*gofall.BulkElementError
+
+		bulkReader, err := gofall.NewBulkReaderWithOptions[gofall.Card](
+			bytes.NewBufferString(testData),
+			gofall.BulkReaderOptions{
+				SkipInvalid: true,
+				OnError:     func(e *gofall.BulkElementError) { reported = append(reported, e) },
+			},
+		)
+		if err != nil {
+			t.Fatalf("failed to create new bulk reader: %v", err)
+		}
+
+		numCards := 0
+
+		for {
+			_, err := bulkReader.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			if err != nil {
+				t.Fatalf("got unexpected error while reading next card: %v", err)
+			}
+
+			numCards++
+		}
+
+		if numCards != 2 {
+			t.Errorf("expected 2 cards, got %d", numCards)
+		}
+
+		if len(reported) != 1 || reported[0].Index != 1 {
+			t.Errorf("unexpected reported errors: %v", reported)
+		}
+
+		if len(bulkReader.Skipped()) != 0 {
+			t.Errorf("expected no collected errors when OnError is set")
+		}
+	})
+}
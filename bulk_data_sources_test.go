@@ -0,0 +1,41 @@
+package gofall
+
+import "testing"
+
+func Test_getBulkDataSources(t *testing.T) {
+	t.Parallel()
+
+	sources := getBulkDataSources([]BulkDataSource{
+		{ID: "1", Type: BulkDataTypeOracleCards},
+		{ID: "2", Type: BulkDataTypeRulings},
+		{ID: "3", Type: "unknown_export"},
+	})
+
+	if sources.OracleCards == nil || sources.OracleCards.ID != "1" {
+		t.Errorf("unexpected OracleCards: %v", sources.OracleCards)
+	}
+
+	if sources.Rulings == nil || sources.Rulings.ID != "2" {
+		t.Errorf("unexpected Rulings: %v", sources.Rulings)
+	}
+
+	if sources.AllCards != nil {
+		t.Errorf("expected nil AllCards, got %v", sources.AllCards)
+	}
+
+	if other := sources.Other["unknown_export"]; other == nil || other.ID != "3" {
+		t.Errorf("expected unknown type in Other, got %v", sources.Other)
+	}
+
+	if got := sources.Get(BulkDataTypeRulings); got != sources.Rulings {
+		t.Errorf("Get returned %v instead of Rulings", got)
+	}
+
+	if got := sources.Get("unknown_export"); got == nil || got.ID != "3" {
+		t.Errorf("Get returned %v for unknown type", got)
+	}
+
+	if got := sources.Get("missing"); got != nil {
+		t.Errorf("expected nil for missing type, got %v", got)
+	}
+}
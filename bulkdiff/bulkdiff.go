@@ -0,0 +1,368 @@
+// Package bulkdiff compares two snapshots of Scryfall's card bulk data,
+// such as two days' downloads of default_cards, and reports what changed.
+//
+// Cards are matched by their Scryfall ID.  Only the previous snapshot is
+// held in memory, and only the fields being compared are kept, so full-size
+// exports can be diffed.
+//
+// DiffLegalities narrows a diff down to per-format legality changes,
+// such as cards being banned or rotating out of Standard.
+package bulkdiff
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/SethCurry/gofall"
+)
+
+// Reader is a source of cards.  It is satisfied by both
+// *gofall.BulkReader[gofall.Card] and *gofall.ParallelBulkReader[gofall.Card].
+type Reader interface {
+	Next() (*gofall.Card, error)
+}
+
+// Kind describes how a card differs between two snapshots.
+type Kind string
+
+const (
+	// KindAdded is a printing that is only in the new snapshot.
+	KindAdded Kind = "added"
+
+	// KindRemoved is a printing that is only in the old snapshot.
+	KindRemoved Kind = "removed"
+
+	// KindChanged is a printing in both snapshots with different field values.
+	KindChanged Kind = "changed"
+)
+
+// Field is a group of card fields that can be compared.
+type Field string
+
+const (
+	// FieldPrices compares every price in Card.Prices.
+	FieldPrices Field = "prices"
+
+	// FieldLegalities compares the legality of the card in every format.
+	FieldLegalities Field = "legalities"
+
+	// FieldOracleText compares Card.OracleText, and the oracle text of
+	// each of Card.CardFaces, where multi-faced cards keep it.
+	FieldOracleText Field = "oracle_text"
+
+	// FieldImageURIs compares every URI in Card.ImageURIs and in the image
+	// URIs of each of Card.CardFaces.  Scryfall changes the version query
+	// parameter whenever an image is updated.
+	FieldImageURIs Field = "image_uris"
+)
+
+// AllFields returns every Field that can be compared.
+func AllFields() []Field {
+	return []Field{
+		FieldPrices,
+		FieldLegalities,
+		FieldOracleText,
+		FieldImageURIs,
+	}
+}
+
+// Change is a single field that differs between two versions of a card.
+type Change struct {
+	// Field is the path of the changed value, such as "prices.usd",
+	// "legalities.modern" or "card_faces[1].oracle_text".
+	Field string `json:"field"`
+
+	// Old is the value in the old snapshot.  Null values are empty.
+	Old string `json:"old"`
+
+	// New is the value in the new snapshot.  Null values are empty.
+	New string `json:"new"`
+}
+
+// CardDiff describes how a single printing differs between two snapshots.
+type CardDiff struct {
+	Kind            Kind     `json:"kind"`
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	SetCode         string   `json:"set"`
+	CollectorNumber string   `json:"collector_number"`
+	Changes         []Change `json:"changes,omitempty"`
+}
+
+// Summary counts the printings of each Kind seen while diffing.
+type Summary struct {
+	Added     int `json:"added"`
+	Removed   int `json:"removed"`
+	Changed   int `json:"changed"`
+	Unchanged int `json:"unchanged"`
+}
+
+// Options configures a diff.
+type Options struct {
+	// Fields are the field groups to compare.  Defaults to AllFields().
+	Fields []Field
+}
+
+func (o Options) fields() []Field {
+	if len(o.Fields) == 0 {
+		return AllFields()
+	}
+
+	return o.Fields
+}
+
+// namedValue is a single comparable value and its path.
+type namedValue struct {
+	name  string
+	value string
+}
+
+// snapshotEntry is the part of a card from the old snapshot that is kept in memory.
+type snapshotEntry struct {
+	name            string
+	setCode         string
+	collectorNumber string
+	values          []namedValue
+}
+
+// Compare returns the changes between two versions of the same card in the
+// given field groups, or in all of them if none are given.
+func Compare(oldCard, newCard *gofall.Card, fields ...Field) []Change {
+	if len(fields) == 0 {
+		fields = AllFields()
+	}
+
+	return compareValues(extract(oldCard, fields), extract(newCard, fields))
+}
+
+// compareValues matches values by name, as cards with a different number
+// of faces have different values.  A value only one side has is compared
+// against an empty value.
+func compareValues(oldValues, newValues []namedValue) []Change {
+	var changes []Change
+
+	unmatched := make(map[string]string, len(newValues))
+	for _, newValue := range newValues {
+		unmatched[newValue.name] = newValue.value
+	}
+
+	for _, oldValue := range oldValues {
+		newValue := unmatched[oldValue.name]
+		delete(unmatched, oldValue.name)
+
+		if oldValue.value != newValue {
+			changes = append(changes, Change{
+				Field: oldValue.name,
+				Old:   oldValue.value,
+				New:   newValue,
+			})
+		}
+	}
+
+	for _, newValue := range newValues {
+		if value, ok := unmatched[newValue.name]; ok && value != "" {
+			changes = append(changes, Change{Field: newValue.name, New: value})
+		}
+	}
+
+	return changes
+}
+
+// extract flattens the card's values for the given fields.
+func extract(card *gofall.Card, fields []Field) []namedValue {
+	var values []namedValue
+
+	for _, field := range fields {
+		switch field {
+		case FieldPrices:
+			values = append(values,
+				namedValue{"prices.usd", card.Prices.USD.String()},
+				namedValue{"prices.usd_foil", card.Prices.USDFoil.String()},
+				namedValue{"prices.usd_etched", card.Prices.USDEtched.String()},
+				namedValue{"prices.eur", card.Prices.EUR.String()},
+				namedValue{"prices.eur_foil", card.Prices.EURFoil.String()},
+				namedValue{"prices.eur_etched", card.Prices.EUREtched.String()},
+				namedValue{"prices.tix", card.Prices.Tix.String()},
+			)
+		case FieldLegalities:
+			values = append(values, legalityValues(card.Legality)...)
+		case FieldOracleText:
+			values = append(values, namedValue{"oracle_text", card.OracleText})
+
+			for i, face := range card.CardFaces {
+				values = append(values, namedValue{facePath(i, "oracle_text"), face.OracleText})
+			}
+		case FieldImageURIs:
+			values = append(values, imageURIValues("image_uris", card.ImageURIs)...)
+
+			for i, face := range card.CardFaces {
+				values = append(values, imageURIValues(facePath(i, "image_uris"), face.ImageURIs)...)
+			}
+		}
+	}
+
+	return values
+}
+
+func facePath(index int, field string) string {
+	return fmt.Sprintf("card_faces[%d].%s", index, field)
+}
+
+func imageURIValues(prefix string, uris gofall.ImageURIs) []namedValue {
+	return []namedValue{
+		{prefix + ".small", uris.Small},
+		{prefix + ".normal", uris.Normal},
+		{prefix + ".large", uris.Large},
+		{prefix + ".png", uris.PNG},
+		{prefix + ".art_crop", uris.ArtCrop},
+		{prefix + ".border_crop", uris.BorderCrop},
+	}
+}
+
+func legalityValues(legality gofall.CardLegality) []namedValue {
+	formats := gofall.AllFormats()
+	values := make([]namedValue, 0, len(formats))
+
+	for _, format := range formats {
+		values = append(values, namedValue{"legalities." + format.String(), legality.Get(format).String()})
+	}
+
+	return values
+}
+
+// Diff compares two snapshots and calls emit for every printing that was
+// added, removed or changed.  Unchanged printings are only counted in the
+// returned Summary.
+//
+// The old snapshot is read fully before the new one is streamed.  Changed and
+// added printings are emitted in the order of the new snapshot, followed by
+// removed printings sorted by ID.  If emit returns an error, diffing stops
+// and the error is returned.
+func Diff(oldSnapshot, newSnapshot Reader, opts Options, emit func(CardDiff) error) (Summary, error) {
+	var summary Summary
+
+	fields := opts.fields()
+
+	index, err := indexSnapshot(oldSnapshot, fields)
+	if err != nil {
+		return summary, fmt.Errorf("failed to read old snapshot: %w", err)
+	}
+
+	for {
+		card, err := newSnapshot.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return summary, fmt.Errorf("failed to read new snapshot: %w", err)
+		}
+
+		diff := CardDiff{
+			ID:              card.ID,
+			Name:            card.Name,
+			SetCode:         card.SetCode,
+			CollectorNumber: card.CollectorNumber,
+		}
+
+		entry, ok := index[card.ID]
+		if !ok {
+			diff.Kind = KindAdded
+			summary.Added++
+		} else {
+			delete(index, card.ID)
+
+			diff.Changes = compareValues(entry.values, extract(card, fields))
+			if len(diff.Changes) == 0 {
+				summary.Unchanged++
+
+				continue
+			}
+
+			diff.Kind = KindChanged
+			summary.Changed++
+		}
+
+		if err := emit(diff); err != nil {
+			return summary, err
+		}
+	}
+
+	removedIDs := make([]string, 0, len(index))
+	for id := range index {
+		removedIDs = append(removedIDs, id)
+	}
+
+	sort.Strings(removedIDs)
+
+	for _, id := range removedIDs {
+		entry := index[id]
+		summary.Removed++
+
+		err := emit(CardDiff{
+			Kind:            KindRemoved,
+			ID:              id,
+			Name:            entry.name,
+			SetCode:         entry.setCode,
+			CollectorNumber: entry.collectorNumber,
+		})
+		if err != nil {
+			return summary, err
+		}
+	}
+
+	return summary, nil
+}
+
+func indexSnapshot(snapshot Reader, fields []Field) (map[string]snapshotEntry, error) {
+	index := map[string]snapshotEntry{}
+
+	for {
+		card, err := snapshot.Next()
+		if errors.Is(err, io.EOF) {
+			return index, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		index[card.ID] = snapshotEntry{
+			name:            card.Name,
+			setCode:         card.SetCode,
+			collectorNumber: card.CollectorNumber,
+			values:          extract(card, fields),
+		}
+	}
+}
+
+// DiffFiles is like Diff, but reads the snapshots from JSON files on disk
+// such as those downloaded from a bulk data source's DownloadURI.
+func DiffFiles(oldPath, newPath string, opts Options, emit func(CardDiff) error) (Summary, error) {
+	oldFd, err := os.Open(oldPath)
+	if err != nil {
+		return Summary{}, fmt.Errorf("failed to open old snapshot: %w", err)
+	}
+	defer oldFd.Close()
+
+	newFd, err := os.Open(newPath)
+	if err != nil {
+		return Summary{}, fmt.Errorf("failed to open new snapshot: %w", err)
+	}
+	defer newFd.Close()
+
+	oldReader, err := gofall.NewBulkReader[gofall.Card](oldFd)
+	if err != nil {
+		return Summary{}, fmt.Errorf("failed to create bulk reader for old snapshot: %w", err)
+	}
+
+	newReader, err := gofall.NewBulkReader[gofall.Card](newFd)
+	if err != nil {
+		return Summary{}, fmt.Errorf("failed to create bulk reader for new snapshot: %w", err)
+	}
+
+	return Diff(oldReader, newReader, opts, emit)
+}
@@ -0,0 +1,223 @@
+package bulkdiff_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/SethCurry/gofall"
+	"github.com/SethCurry/gofall/bulkdiff"
+)
+
+type sliceReader struct {
+	cards []gofall.Card
+}
+
+func (s *sliceReader) Next() (*gofall.Card, error) {
+	if len(s.cards) == 0 {
+		return nil, io.EOF
+	}
+
+	card := s.cards[0]
+	s.cards = s.cards[1:]
+
+	return &card, nil
+}
+
+func loadCards(t *testing.T) []gofall.Card {
+	t.Helper()
+
+	contents, err := os.ReadFile("../test/cards.json")
+	if err != nil {
+		t.Fatalf("failed to read test cards file: %v", err)
+	}
+
+	var cards []gofall.Card
+
+	if err := json.Unmarshal(contents, &cards); err != nil {
+		t.Fatalf("failed to unmarshal test cards: %v", err)
+	}
+
+	return cards
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	oldCards := loadCards(t)
+	newCards := loadCards(t)
+
+	// Remove the first card, add a new one, and change the second.
+	removed := newCards[0]
+	newCards = newCards[1:]
+
+	newCards[0].Prices.USD = gofall.NewPrice(999)
+	newCards[0].Legality.Modern = gofall.LegalityBanned
+
+	added := gofall.Card{ID: "new-card", Name: "New Card"}
+	newCards = append(newCards, added)
+
+	var diffs []bulkdiff.CardDiff
+
+	summary, err := bulkdiff.Diff(
+		&sliceReader{oldCards},
+		&sliceReader{newCards},
+		bulkdiff.Options{},
+		func(d bulkdiff.CardDiff) error {
+			diffs = append(diffs, d)
+
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("failed to diff snapshots: %v", err)
+	}
+
+	expectedSummary := bulkdiff.Summary{Added: 1, Removed: 1, Changed: 1, Unchanged: len(oldCards) - 2}
+	if summary != expectedSummary {
+		t.Errorf("unexpected summary: got %+v, want %+v", summary, expectedSummary)
+	}
+
+	if len(diffs) != 3 {
+		t.Fatalf("expected 3 diffs, got %d", len(diffs))
+	}
+
+	changed := diffs[0]
+	if changed.Kind != bulkdiff.KindChanged || changed.ID != newCards[0].ID {
+		t.Errorf("unexpected first diff: %+v", changed)
+	}
+
+	expectedChanges := []bulkdiff.Change{
+		{Field: "prices.usd", Old: oldCards[1].Prices.USD.String(), New: "9.99"},
+		{Field: "legalities.modern", Old: "legal", New: "banned"},
+	}
+
+	if len(changed.Changes) != len(expectedChanges) {
+		t.Fatalf("unexpected changes: %+v", changed.Changes)
+	}
+
+	for i, change := range expectedChanges {
+		if changed.Changes[i] != change {
+			t.Errorf("change %d: got %+v, want %+v", i, changed.Changes[i], change)
+		}
+	}
+
+	if diffs[1].Kind != bulkdiff.KindAdded || diffs[1].ID != added.ID {
+		t.Errorf("unexpected second diff: %+v", diffs[1])
+	}
+
+	if diffs[2].Kind != bulkdiff.KindRemoved || diffs[2].ID != removed.ID || diffs[2].Name != removed.Name {
+		t.Errorf("unexpected third diff: %+v", diffs[2])
+	}
+}
+
+func TestDiff_Fields(t *testing.T) {
+	t.Parallel()
+
+	oldCard := gofall.Card{ID: "a", OracleText: "Flying"}
+	newCard := gofall.Card{ID: "a", OracleText: "Flying, vigilance"}
+	newCard.Prices.USD = gofall.NewPrice(100)
+
+	changes := bulkdiff.Compare(&oldCard, &newCard, bulkdiff.FieldOracleText)
+	if len(changes) != 1 || changes[0].Field != "oracle_text" {
+		t.Errorf("unexpected changes: %+v", changes)
+	}
+
+	changes = bulkdiff.Compare(&oldCard, &newCard)
+	if len(changes) != 2 {
+		t.Errorf("expected 2 changes, got %+v", changes)
+	}
+}
+
+func TestDiffFiles_CardFaces(t *testing.T) {
+	t.Parallel()
+
+	var diffs []bulkdiff.CardDiff
+
+	summary, err := bulkdiff.DiffFiles(
+		"../test/dfc_cards.json",
+		"../test/dfc_cards_updated.json",
+		bulkdiff.Options{Fields: []bulkdiff.Field{bulkdiff.FieldOracleText, bulkdiff.FieldImageURIs}},
+		func(d bulkdiff.CardDiff) error {
+			diffs = append(diffs, d)
+
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("failed to diff files: %v", err)
+	}
+
+	if summary.Changed != 1 || len(diffs) != 1 {
+		t.Fatalf("expected the transformed card to change, got %+v", summary)
+	}
+
+	changes := map[string]bulkdiff.Change{}
+	for _, change := range diffs[0].Changes {
+		changes[change.Field] = change
+	}
+
+	if change := changes["card_faces[1].oracle_text"]; change.Old != "Flying" || change.New != "Flying, vigilance" {
+		t.Errorf("expected the back face's oracle text to change, got %+v", diffs[0].Changes)
+	}
+
+	if _, ok := changes["card_faces[0].oracle_text"]; ok {
+		t.Errorf("did not expect the front face's oracle text to change")
+	}
+
+	// 6 image URIs for each of the two faces.
+	if len(changes) != 13 {
+		t.Errorf("expected the image URIs of both faces to change, got %+v", diffs[0].Changes)
+	}
+}
+
+func TestCompare_FaceCount(t *testing.T) {
+	t.Parallel()
+
+	oldCard := gofall.Card{ID: "a", CardFaces: []gofall.CardFace{{OracleText: "Flying"}}}
+	newCard := gofall.Card{ID: "a", CardFaces: []gofall.CardFace{{OracleText: "Flying"}, {OracleText: "Trample"}}}
+
+	changes := bulkdiff.Compare(&oldCard, &newCard, bulkdiff.FieldOracleText)
+
+	expected := bulkdiff.Change{Field: "card_faces[1].oracle_text", New: "Trample"}
+	if len(changes) != 1 || changes[0] != expected {
+		t.Errorf("unexpected changes: %+v", changes)
+	}
+}
+
+func TestDiffFiles_JSONReport(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	report := bulkdiff.NewJSONReport(&buf)
+
+	summary, err := bulkdiff.DiffFiles("../test/cards.json", "../test/cards.json", bulkdiff.Options{}, report.Write)
+	if err != nil {
+		t.Fatalf("failed to diff files: %v", err)
+	}
+
+	if err := report.Close(summary); err != nil {
+		t.Fatalf("failed to close report: %v", err)
+	}
+
+	var decoded struct {
+		Changes []bulkdiff.CardDiff `json:"changes"`
+		Summary bulkdiff.Summary    `json:"summary"`
+	}
+
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("report is not valid JSON: %v\n%s", err, buf.String())
+	}
+
+	if len(decoded.Changes) != 0 || decoded.Summary.Unchanged != 10 {
+		t.Errorf("unexpected report: %s", buf.String())
+	}
+
+	if err := report.Write(bulkdiff.CardDiff{}); !errors.Is(err, bulkdiff.ErrReportClosed) {
+		t.Errorf("expected ErrReportClosed, got %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+package bulkdiff
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/SethCurry/gofall"
+)
+
+// LegalityChange classifies a change in a card's legality in a single format.
+type LegalityChange string
+
+const (
+	// LegalityChangeBanned is a card that became banned.
+	LegalityChangeBanned LegalityChange = "banned"
+
+	// LegalityChangeUnbanned is a card that was banned and is now legal or restricted.
+	LegalityChangeUnbanned LegalityChange = "unbanned"
+
+	// LegalityChangeRestricted is a card that became restricted, other than by being unbanned.
+	LegalityChangeRestricted LegalityChange = "restricted"
+
+	// LegalityChangeUnrestricted is a card that was restricted and is now legal.
+	LegalityChangeUnrestricted LegalityChange = "unrestricted"
+
+	// LegalityChangeRotatedOut is a legal or restricted card that is no longer legal,
+	// e.g. because its set left Standard.
+	LegalityChangeRotatedOut LegalityChange = "rotated_out"
+
+	// LegalityChangeRotatedIn is a card that was not legal and now is,
+	// e.g. because it was printed in a new Standard set.
+	LegalityChangeRotatedIn LegalityChange = "rotated_in"
+)
+
+// LegalityEvent is a change in a card's legality in a single format.
+type LegalityEvent struct {
+	Change   LegalityChange  `json:"change"`
+	Format   gofall.Format   `json:"format"`
+	OracleID string          `json:"oracle_id"`
+	Name     string          `json:"name"`
+	Old      gofall.Legality `json:"old"`
+	New      gofall.Legality `json:"new"`
+}
+
+// classifyLegality returns the kind of change between two legalities,
+// or an empty string if they are equal.
+func classifyLegality(oldLegality, newLegality gofall.Legality) LegalityChange {
+	switch {
+	case oldLegality == newLegality:
+		return ""
+	case newLegality == gofall.LegalityBanned:
+		return LegalityChangeBanned
+	case oldLegality == gofall.LegalityBanned:
+		return LegalityChangeUnbanned
+	case newLegality == gofall.LegalityRestricted:
+		return LegalityChangeRestricted
+	case oldLegality == gofall.LegalityRestricted && newLegality == gofall.LegalityLegal:
+		return LegalityChangeUnrestricted
+	case newLegality == gofall.LegalityNotLegal:
+		return LegalityChangeRotatedOut
+	default:
+		return LegalityChangeRotatedIn
+	}
+}
+
+// CompareLegality returns an event for every format in which the legality
+// of the card changed between the two versions.  The events use the
+// name and Oracle ID of newCard.
+func CompareLegality(oldCard, newCard *gofall.Card) []LegalityEvent {
+	return compareLegality(oldCard.Legality, newCard)
+}
+
+func compareLegality(oldLegality gofall.CardLegality, newCard *gofall.Card) []LegalityEvent {
+	var events []LegalityEvent
+
+	for _, format := range gofall.AllFormats() {
+		oldValue := oldLegality.Get(format)
+		newValue := newCard.Legality.Get(format)
+
+		change := classifyLegality(oldValue, newValue)
+		if change == "" {
+			continue
+		}
+
+		events = append(events, LegalityEvent{
+			Change:   change,
+			Format:   format,
+			OracleID: oracleID(newCard),
+			Name:     newCard.Name,
+			Old:      oldValue,
+			New:      newValue,
+		})
+	}
+
+	return events
+}
+
+// oracleID returns the card's Oracle ID.  Reversible cards only have
+// Oracle IDs on their faces, so the front face's is used for them.
+func oracleID(card *gofall.Card) string {
+	if card.OracleID == "" && len(card.CardFaces) > 0 {
+		return card.CardFaces[0].OracleID
+	}
+
+	return card.OracleID
+}
+
+// DiffLegalities compares the legalities of cards in two snapshots and calls
+// emit for every change.  Legality is a property of a card rather than a
+// printing, so cards are matched by Oracle ID and each card is only compared
+// once, however many printings it has.  Cards that were added or removed
+// between the snapshots do not produce events, nor do cards without an
+// Oracle ID.
+//
+// If emit returns an error, diffing stops and the error is returned.
+func DiffLegalities(oldSnapshot, newSnapshot Reader, emit func(LegalityEvent) error) error {
+	index := map[string]gofall.CardLegality{}
+
+	for {
+		card, err := oldSnapshot.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read old snapshot: %w", err)
+		}
+
+		id := oracleID(card)
+		if id == "" {
+			continue
+		}
+
+		if _, ok := index[id]; !ok {
+			index[id] = card.Legality
+		}
+	}
+
+	for {
+		card, err := newSnapshot.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read new snapshot: %w", err)
+		}
+
+		id := oracleID(card)
+
+		oldLegality, ok := index[id]
+		if !ok {
+			continue
+		}
+
+		// Only compare the first printing of each card.
+		delete(index, id)
+
+		for _, event := range compareLegality(oldLegality, card) {
+			if err := emit(event); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package bulkdiff_test
+
+import (
+	"testing"
+
+	"github.com/SethCurry/gofall"
+	"github.com/SethCurry/gofall/bulkdiff"
+)
+
+func TestCompareLegality(t *testing.T) {
+	t.Parallel()
+
+	oldCard := gofall.Card{OracleID: "a", Name: "Card A"}
+	newCard := oldCard
+
+	for _, format := range gofall.AllFormats() {
+		_ = oldCard.Legality.Set(format, gofall.LegalityLegal)
+		_ = newCard.Legality.Set(format, gofall.LegalityLegal)
+	}
+
+	oldCard.Legality.Vintage = gofall.LegalityRestricted
+	oldCard.Legality.Legacy = gofall.LegalityBanned
+	oldCard.Legality.Standard = gofall.LegalityLegal
+	oldCard.Legality.Pioneer = gofall.LegalityNotLegal
+
+	newCard.Legality.Modern = gofall.LegalityBanned
+	newCard.Legality.Vintage = gofall.LegalityLegal
+	newCard.Legality.Legacy = gofall.LegalityLegal
+	newCard.Legality.Standard = gofall.LegalityNotLegal
+	newCard.Legality.Pauper = gofall.LegalityRestricted
+
+	expected := map[gofall.Format]bulkdiff.LegalityChange{
+		gofall.FormatStandard: bulkdiff.LegalityChangeRotatedOut,
+		gofall.FormatPioneer:  bulkdiff.LegalityChangeRotatedIn,
+		gofall.FormatModern:   bulkdiff.LegalityChangeBanned,
+		gofall.FormatLegacy:   bulkdiff.LegalityChangeUnbanned,
+		gofall.FormatPauper:   bulkdiff.LegalityChangeRestricted,
+		gofall.FormatVintage:  bulkdiff.LegalityChangeUnrestricted,
+	}
+
+	events := bulkdiff.CompareLegality(&oldCard, &newCard)
+	if len(events) != len(expected) {
+		t.Fatalf("expected %d events, got %+v", len(expected), events)
+	}
+
+	for _, event := range events {
+		if event.Change != expected[event.Format] {
+			t.Errorf("unexpected change for %s: got %s, want %s", event.Format, event.Change, expected[event.Format])
+		}
+
+		if event.OracleID != "a" || event.Name != "Card A" {
+			t.Errorf("unexpected card in event: %+v", event)
+		}
+	}
+}
+
+func TestDiffLegalities(t *testing.T) {
+	t.Parallel()
+
+	oldCards := loadCards(t)
+	newCards := loadCards(t)
+
+	newCards[0].Legality.Modern = gofall.LegalityBanned
+
+	// A second printing of the same card should not produce duplicate events.
+	newCards = append(newCards, newCards[0])
+
+	var events []bulkdiff.LegalityEvent
+
+	err := bulkdiff.DiffLegalities(&sliceReader{oldCards}, &sliceReader{newCards}, func(e bulkdiff.LegalityEvent) error {
+		events = append(events, e)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to diff legalities: %v", err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %+v", events)
+	}
+
+	if events[0].Change != bulkdiff.LegalityChangeBanned || events[0].Format != gofall.FormatModern {
+		t.Errorf("unexpected event: %+v", events[0])
+	}
+
+	if events[0].OracleID != oldCards[0].OracleID {
+		t.Errorf("event has Oracle ID %q, expected %q", events[0].OracleID, oldCards[0].OracleID)
+	}
+}
+
+func TestDiffLegalities_Reversible(t *testing.T) {
+	t.Parallel()
+
+	// Reversible cards only have Oracle IDs on their faces.
+	reversible := func(oracleID, name string, modern gofall.Legality) gofall.Card {
+		card := gofall.Card{
+			Name:      name + " // " + name,
+			CardFaces: []gofall.CardFace{{OracleID: oracleID, Name: name}, {OracleID: oracleID, Name: name}},
+		}
+		card.Legality.Modern = modern
+
+		return card
+	}
+
+	oldCards := []gofall.Card{
+		reversible("a", "Card A", gofall.LegalityLegal),
+		reversible("b", "Card B", gofall.LegalityLegal),
+	}
+	newCards := []gofall.Card{
+		reversible("a", "Card A", gofall.LegalityBanned),
+		reversible("b", "Card B", gofall.LegalityNotLegal),
+	}
+
+	events := map[string]bulkdiff.LegalityEvent{}
+
+	err := bulkdiff.DiffLegalities(&sliceReader{oldCards}, &sliceReader{newCards}, func(e bulkdiff.LegalityEvent) error {
+		events[e.OracleID] = e
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to diff legalities: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected an event for each card, got %+v", events)
+	}
+
+	if events["a"].Change != bulkdiff.LegalityChangeBanned || events["b"].Change != bulkdiff.LegalityChangeRotatedOut {
+		t.Errorf("unexpected events: %+v", events)
+	}
+}
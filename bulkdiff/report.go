@@ -0,0 +1,82 @@
+package bulkdiff
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrReportClosed is returned when writing to a JSONReport that has been closed.
+var ErrReportClosed = errors.New("report is already closed")
+
+// JSONReport streams a diff to an io.Writer as a single JSON document:
+//
+//	{"changes":[...],"summary":{...}}
+//
+// Its Write method can be passed directly to Diff as the emit function,
+// and Close must be called with the resulting Summary to finish the document.
+type JSONReport struct {
+	w       io.Writer
+	started bool
+	closed  bool
+}
+
+// NewJSONReport creates a JSONReport that writes to w.
+func NewJSONReport(w io.Writer) *JSONReport {
+	return &JSONReport{w: w}
+}
+
+// Write appends a single CardDiff to the report.
+func (j *JSONReport) Write(diff CardDiff) error {
+	if j.closed {
+		return ErrReportClosed
+	}
+
+	prefix := ","
+	if !j.started {
+		prefix = `{"changes":[`
+		j.started = true
+	}
+
+	marshalled, err := json.Marshal(diff)
+	if err != nil {
+		return fmt.Errorf("failed to marshal card diff: %w", err)
+	}
+
+	if _, err := io.WriteString(j.w, prefix); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	if _, err := j.w.Write(marshalled); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	return nil
+}
+
+// Close finishes the document by writing the summary.
+// It does not close the underlying writer.
+func (j *JSONReport) Close(summary Summary) error {
+	if j.closed {
+		return ErrReportClosed
+	}
+
+	j.closed = true
+
+	prefix := `],"summary":`
+	if !j.started {
+		prefix = `{"changes":[],"summary":`
+	}
+
+	marshalled, err := json.Marshal(summary)
+	if err != nil {
+		return fmt.Errorf("failed to marshal summary: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(j.w, "%s%s}\n", prefix, marshalled); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	return nil
+}
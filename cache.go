@@ -0,0 +1,268 @@
+package gofall
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// CachedResponse is a response stored in a CacheBackend.
+type CachedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+
+	// ExpiresAt is when the response must be revalidated with Scryfall
+	// before it is used again.
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// response recreates the http.Response for req.
+func (c *CachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", c.StatusCode, http.StatusText(c.StatusCode)),
+		StatusCode:    c.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        c.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(c.Body)),
+		ContentLength: int64(len(c.Body)),
+		Request:       req,
+	}
+}
+
+// CacheBackend stores cached responses.  NewMemoryCache and NewDiskCache
+// provide backends, and other storage can be used by implementing it.
+//
+// Caching is best effort, so backends should not fail: a response that
+// cannot be read is a miss, and one that cannot be stored is dropped.
+// Implementations must be safe to use from multiple goroutines.
+type CacheBackend interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, response *CachedResponse)
+	Delete(key string)
+}
+
+// DefaultCacheTTLs returns the TTLs used when CacheOptions.TTLs is nil.
+// Card data changes at most a few times a day, while random
+// cards and searches for new cards should never be stale.
+func DefaultCacheTTLs() map[string]time.Duration {
+	return map[string]time.Duration{
+		"/cards/":             12 * time.Hour,
+		"/cards/random":       0,
+		"/cards/search":       time.Hour,
+		"/cards/autocomplete": time.Hour,
+		"/bulk-data":          time.Hour,
+		"/catalog/":           24 * time.Hour,
+	}
+}
+
+// CacheOptions configures a Cache.
+// The zero value is valid and uses the defaults described on each field.
+type CacheOptions struct {
+	// Backend stores the responses.  Defaults to NewMemoryCache(1000).
+	Backend CacheBackend
+
+	// TTLs is how long responses are fresh for, keyed by URL path prefix.
+	// The longest matching prefix is used, and responses for paths
+	// without a match, or with a TTL of zero, are not cached.
+	// Defaults to DefaultCacheTTLs().
+	TTLs map[string]time.Duration
+
+	// Clock decides when responses expire.  Defaults to SystemClock.
+	Clock Clock
+}
+
+// CacheStats counts how a Cache has handled requests.
+type CacheStats struct {
+	// Hits are requests answered from the cache without a request to Scryfall.
+	Hits int64
+
+	// Revalidations are requests for stale responses that Scryfall
+	// confirmed were unchanged, using ETag or Last-Modified.
+	Revalidations int64
+
+	// Misses are cacheable requests that had to be fetched from Scryfall.
+	Misses int64
+}
+
+// NewCache creates a Cache for use with ClientOptions.
+func NewCache(opts CacheOptions) *Cache {
+	defaultMaxEntries := 1000
+
+	if opts.Backend == nil {
+		opts.Backend = NewMemoryCache(defaultMaxEntries)
+	}
+
+	if opts.TTLs == nil {
+		opts.TTLs = DefaultCacheTTLs()
+	}
+
+	if opts.Clock == nil {
+		opts.Clock = SystemClock{}
+	}
+
+	return &Cache{
+		backend: opts.Backend,
+		ttls:    opts.TTLs,
+		clock:   opts.Clock,
+	}
+}
+
+// Cache caches successful GET responses from Scryfall.  Fresh responses
+// are returned without a request.  Stale ones are revalidated with a
+// conditional request if Scryfall sent an ETag or Last-Modified header,
+// and fetched again otherwise.
+type Cache struct {
+	backend CacheBackend
+	ttls    map[string]time.Duration
+	clock   Clock
+
+	hits          atomic.Int64
+	revalidations atomic.Int64
+	misses        atomic.Int64
+}
+
+// Stats returns how the cache has handled requests so far.
+func (c *Cache) Stats() CacheStats {
+	return CacheStats{
+		Hits:          c.hits.Load(),
+		Revalidations: c.revalidations.Load(),
+		Misses:        c.misses.Load(),
+	}
+}
+
+// ttl returns how long responses for the path are fresh for.
+func (c *Cache) ttl(path string) time.Duration {
+	longest := -1
+
+	var ttl time.Duration
+
+	for prefix, prefixTTL := range c.ttls {
+		if strings.HasPrefix(path, prefix) && len(prefix) > longest {
+			longest = len(prefix)
+			ttl = prefixTTL
+		}
+	}
+
+	return ttl
+}
+
+// wrap returns a transport that answers requests from the cache
+// before passing them to inner.
+func (c *Cache) wrap(inner http.RoundTripper) http.RoundTripper {
+	return &cachingTransport{cache: c, inner: inner}
+}
+
+type cachingTransport struct {
+	cache *Cache
+	inner http.RoundTripper
+}
+
+func (c *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ttl := c.cache.ttl(req.URL.Path)
+	if req.Method != http.MethodGet || ttl <= 0 {
+		return c.inner.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	cached, ok := c.cache.backend.Get(key)
+
+	if ok && c.cache.clock.Now().Before(cached.ExpiresAt) {
+		c.cache.hits.Add(1)
+		traceFrom(req.Context()).cacheHit = true
+
+		return cached.response(req), nil
+	}
+
+	if ok && hasValidators(cached) {
+		return c.revalidate(req, key, cached, ttl)
+	}
+
+	c.cache.misses.Add(1)
+
+	resp, err := c.inner.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.store(req, key, resp, ttl)
+}
+
+// hasValidators reports whether a stale response can be revalidated
+// with a conditional request.
+func hasValidators(cached *CachedResponse) bool {
+	return cached.Header.Get("ETag") != "" || cached.Header.Get("Last-Modified") != ""
+}
+
+// revalidate asks Scryfall whether a stale response is still current,
+// reusing it if so and storing the new response otherwise.
+func (c *cachingTransport) revalidate(
+	req *http.Request,
+	key string,
+	cached *CachedResponse,
+	ttl time.Duration,
+) (*http.Response, error) {
+	conditional := req.Clone(req.Context())
+
+	if etag := cached.Header.Get("ETag"); etag != "" {
+		conditional.Header.Set("If-None-Match", etag)
+	}
+
+	if lastModified := cached.Header.Get("Last-Modified"); lastModified != "" {
+		conditional.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	resp, err := c.inner.RoundTrip(conditional)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusNotModified {
+		c.cache.misses.Add(1)
+
+		return c.store(req, key, resp, ttl)
+	}
+
+	resp.Body.Close()
+	c.cache.revalidations.Add(1)
+	traceFrom(req.Context()).cacheHit = true
+
+	// Copied, as the backend may share the cached response between requests.
+	refreshed := *cached
+	refreshed.ExpiresAt = c.cache.clock.Now().Add(ttl)
+	c.cache.backend.Set(key, &refreshed)
+
+	return refreshed.response(req), nil
+}
+
+// store saves a successful response in the cache, returning
+// a copy of it for the caller to read.
+func (c *cachingTransport) store(req *http.Request, key string, resp *http.Response, ttl time.Duration) (*http.Response, error) {
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	cached := &CachedResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Body:       body,
+		ExpiresAt:  c.cache.clock.Now().Add(ttl),
+	}
+
+	c.cache.backend.Set(key, cached)
+
+	return cached.response(req), nil
+}
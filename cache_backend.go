@@ -0,0 +1,165 @@
+package gofall
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// NewMemoryCache creates an in-memory CacheBackend holding at most
+// maxEntries responses.  When it is full, the least recently used
+// response is evicted.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    map[string]*list.Element{},
+	}
+}
+
+// MemoryCache is an in-memory, least recently used CacheBackend.
+type MemoryCache struct {
+	maxEntries int
+
+	lock sync.Mutex
+
+	// order holds memoryCacheEntry values, most recently used first.
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key      string
+	response *CachedResponse
+}
+
+// Get implements the CacheBackend interface.
+func (m *MemoryCache) Get(key string) (*CachedResponse, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	m.order.MoveToFront(elem)
+
+	return elem.Value.(*memoryCacheEntry).response, true //nolint:forcetypeassert
+}
+
+// Set implements the CacheBackend interface.
+func (m *MemoryCache) Set(key string, response *CachedResponse) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if elem, ok := m.entries[key]; ok {
+		elem.Value.(*memoryCacheEntry).response = response //nolint:forcetypeassert
+		m.order.MoveToFront(elem)
+
+		return
+	}
+
+	m.entries[key] = m.order.PushFront(&memoryCacheEntry{key: key, response: response})
+
+	for m.maxEntries > 0 && m.order.Len() > m.maxEntries {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryCacheEntry).key) //nolint:forcetypeassert
+	}
+}
+
+// Delete implements the CacheBackend interface.
+func (m *MemoryCache) Delete(key string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if elem, ok := m.entries[key]; ok {
+		m.order.Remove(elem)
+		delete(m.entries, key)
+	}
+}
+
+// Len returns the number of responses in the cache.
+func (m *MemoryCache) Len() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.order.Len()
+}
+
+// NewDiskCache creates a CacheBackend storing responses as files in dir,
+// creating it if it does not exist.  Responses are only removed when they
+// are replaced, so the directory should be cleaned up externally if needed.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	return &DiskCache{dir: dir}, nil
+}
+
+// DiskCache is a CacheBackend that persists responses to disk,
+// so they survive restarts.
+type DiskCache struct {
+	dir string
+}
+
+func (d *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get implements the CacheBackend interface.
+func (d *DiskCache) Get(key string) (*CachedResponse, bool) {
+	contents, err := os.ReadFile(d.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var response CachedResponse
+
+	if err := json.Unmarshal(contents, &response); err != nil {
+		return nil, false
+	}
+
+	return &response, true
+}
+
+// Set implements the CacheBackend interface.  The response is written
+// to a temporary file first so readers never see a partial response.
+func (d *DiskCache) Set(key string, response *CachedResponse) {
+	contents, err := json.Marshal(response)
+	if err != nil {
+		return
+	}
+
+	tmp, err := os.CreateTemp(d.dir, "response-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(contents); err != nil {
+		tmp.Close()
+
+		return
+	}
+
+	if err := tmp.Close(); err != nil {
+		return
+	}
+
+	_ = os.Rename(tmp.Name(), d.path(key))
+}
+
+// Delete implements the CacheBackend interface.
+func (d *DiskCache) Delete(key string) {
+	_ = os.Remove(d.path(key))
+}
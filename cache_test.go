@@ -0,0 +1,178 @@
+package gofall
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testTransport sends every request to a test server.
+type testTransport struct {
+	target *url.URL
+}
+
+func (t testTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newCacheTestClient(t *testing.T, cache *Cache) (*Client, *atomic.Int32) {
+	t.Helper()
+
+	var hits atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = io.WriteString(w, `{"object":"card","name":"Lightning Bolt"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	target, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("failed to parse test server URL: %v", err)
+	}
+
+	client := NewClientWithOptions(ClientOptions{
+		HTTPClient: &http.Client{Transport: testTransport{target: target}},
+		Cache:      cache,
+	})
+
+	return client, &hits
+}
+
+func Test_Cache(t *testing.T) {
+	t.Parallel()
+
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	cache := NewCache(CacheOptions{Clock: clock})
+
+	client, hits := newCacheTestClient(t, cache)
+
+	named := func() {
+		t.Helper()
+
+		card, err := client.Card.Named(context.Background(), CardNamedRequest{Exact: "Lightning Bolt"})
+		if err != nil {
+			t.Fatalf("failed to get card: %v", err)
+		}
+
+		if card.Name != "Lightning Bolt" {
+			t.Errorf("unexpected card %q", card.Name)
+		}
+	}
+
+	named()
+	named()
+
+	if hits.Load() != 1 {
+		t.Errorf("expected a fresh response to be reused, got %d requests", hits.Load())
+	}
+
+	clock.Advance(13 * time.Hour)
+
+	named()
+
+	if hits.Load() != 2 {
+		t.Errorf("expected a stale response to be revalidated, got %d requests", hits.Load())
+	}
+
+	named()
+
+	if hits.Load() != 2 {
+		t.Errorf("expected a revalidated response to be fresh again, got %d requests", hits.Load())
+	}
+
+	if stats := cache.Stats(); stats != (CacheStats{Hits: 2, Revalidations: 1, Misses: 1}) {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	// Random cards are never cached.
+	for i := 0; i < 2; i++ {
+		if _, err := client.Card.Random(context.Background(), "", RandomCardOptions{}); err != nil {
+			t.Fatalf("failed to get random card: %v", err)
+		}
+	}
+
+	if hits.Load() != 4 {
+		t.Errorf("expected random cards to not be cached, got %d requests", hits.Load())
+	}
+}
+
+func Test_MemoryCache_Evicts(t *testing.T) {
+	t.Parallel()
+
+	cache := NewMemoryCache(2)
+
+	cache.Set("a", &CachedResponse{})
+	cache.Set("b", &CachedResponse{})
+	cache.Get("a")
+	cache.Set("c", &CachedResponse{})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("expected the least recently used entry to be evicted")
+	}
+
+	if _, ok := cache.Get("a"); !ok {
+		t.Errorf("expected a recently used entry to be kept")
+	}
+
+	if cache.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", cache.Len())
+	}
+}
+
+func Test_DiskCache(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	cache, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatalf("failed to create disk cache: %v", err)
+	}
+
+	expires := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cache.Set("key", &CachedResponse{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Etag": {`"v1"`}},
+		Body:       []byte("body"),
+		ExpiresAt:  expires,
+	})
+
+	reopened, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatalf("failed to reopen disk cache: %v", err)
+	}
+
+	cached, ok := reopened.Get("key")
+	if !ok {
+		t.Fatalf("expected response to be persisted")
+	}
+
+	if string(cached.Body) != "body" || cached.Header.Get("ETag") != `"v1"` || !cached.ExpiresAt.Equal(expires) {
+		t.Errorf("unexpected cached response: %+v", cached)
+	}
+
+	reopened.Delete("key")
+
+	if _, ok := cache.Get("key"); ok {
+		t.Errorf("expected response to be deleted")
+	}
+}
@@ -61,9 +61,11 @@ type Card struct {
 	ManaCost        string `json:"mana_cost"`
 	TypeLine        string `json:"type_line"`
 	OracleText      string `json:"oracle_text"`
-	Power           string `json:"power"`
-	Toughness       string `json:"toughness"`
-	Loyalty         string `json:"loyalty"`
+
+	// Power, Toughness and Loyalty are null if the card does not have them.
+	Power     Stat `json:"power"`
+	Toughness Stat `json:"toughness"`
+	Loyalty   Stat `json:"loyalty"`
 
 	HighResImage   bool `json:"highres_image"`
 	HighResScan    bool `json:"highres_scan"`
@@ -107,6 +109,7 @@ type Card struct {
 	Colors        []string     `json:"colors"`
 	ColorIdentity []string     `json:"color_identity"`
 	Keywords      []string     `json:"keywords"`
+	ProducedMana  []string     `json:"produced_mana"`
 	Games         []string     `json:"games"`
 	Finishes      []string     `json:"finishes"`
 	ArtistIDs     []string     `json:"artist_ids"`
@@ -116,6 +119,30 @@ type Card struct {
 	Prices        Prices       `json:"prices"`
 	Legality      CardLegality `json:"legalities"`
 	AllParts      []Part       `json:"all_parts"`
+
+	// CardFaces are the faces of multi-faced cards, e.g. transforming or
+	// modal double-faced cards.  It is empty for single-faced cards.
+	CardFaces []CardFace `json:"card_faces"`
+}
+
+// CardFace is a single face of a multi-faced card.  OracleID is only set
+// on the faces of reversible cards, which have no top-level OracleID.
+type CardFace struct {
+	Object         Object    `json:"object"`
+	OracleID       string    `json:"oracle_id"`
+	Name           string    `json:"name"`
+	ManaCost       string    `json:"mana_cost"`
+	TypeLine       string    `json:"type_line"`
+	OracleText     string    `json:"oracle_text"`
+	FlavorText     string    `json:"flavor_text"`
+	Power          Stat      `json:"power"`
+	Toughness      Stat      `json:"toughness"`
+	Loyalty        Stat      `json:"loyalty"`
+	Colors         []string  `json:"colors"`
+	Artist         string    `json:"artist"`
+	IllustrationID string    `json:"illustration_id"`
+	Layout         string    `json:"layout"`
+	ImageURIs      ImageURIs `json:"image_uris"`
 }
 
 type Part struct {
@@ -127,13 +154,65 @@ type Part struct {
 	URI       string    `json:"uri"`
 }
 
+// Prices are the current prices of a card printing.  Any of them
+// may be null if Scryfall does not know the price.
 type Prices struct {
-	USD       string  `json:"usd"`
-	USDFoil   *string `json:"usd_foil"`
-	USDEtched *string `json:"usd_etched"`
-	EUR       string  `json:"eur"`
-	EURFoil   *string `json:"eur_foil"`
-	Tix       string  `json:"tix"`
+	USD       Price `json:"usd"`
+	USDFoil   Price `json:"usd_foil"`
+	USDEtched Price `json:"usd_etched"`
+	EUR       Price `json:"eur"`
+	EURFoil   Price `json:"eur_foil"`
+	EUREtched Price `json:"eur_etched"`
+	Tix       Price `json:"tix"`
+}
+
+// Get returns the price in the given currency and finish.
+// MTGO has no finishes, so every finish returns the same TIX price.
+func (p Prices) Get(currency Currency, finish Finish) Price {
+	switch currency {
+	case CurrencyUSD:
+		switch finish {
+		case FinishNonfoil:
+			return p.USD
+		case FinishFoil:
+			return p.USDFoil
+		case FinishEtched:
+			return p.USDEtched
+		}
+	case CurrencyEUR:
+		switch finish {
+		case FinishNonfoil:
+			return p.EUR
+		case FinishFoil:
+			return p.EURFoil
+		case FinishEtched:
+			return p.EUREtched
+		}
+	case CurrencyTIX:
+		return p.Tix
+	}
+
+	return Price{}
+}
+
+// Cheapest returns the cheapest price in the given currency across all
+// finishes, and the finish it is for.  The price is null if there is
+// no price in that currency.
+func (p Prices) Cheapest(currency Currency) (Price, Finish) {
+	var (
+		cheapest Price
+		finish   Finish
+	)
+
+	for _, f := range AllFinishes() {
+		price := p.Get(currency, f)
+		if price.Less(cheapest) {
+			cheapest = price
+			finish = f
+		}
+	}
+
+	return cheapest, finish
 }
 
 type RelatedURIs struct {
@@ -15,6 +15,10 @@ import (
 // a search query, name, etc.
 type CardClient struct {
 	client *http.Client
+	clock  Clock
+
+	// images downloads images from Scryfall's CDN.
+	images *http.Client
 }
 
 // CardNamedRequest contains the parameters for a named card search.
@@ -78,7 +82,8 @@ func (c CardNamedRequest) addToQuery(query url.Values) {
 }
 
 // Named searches for a card by its name.
-// Returns an error if more than one card is found.
+// Returns an error if more than one card is found.  For a Fuzzy name
+// that error is an *AmbiguousNameError listing the cards it could be.
 func (c *CardClient) Named(ctx context.Context, options CardNamedRequest) (*Card, error) {
 	if err := options.validate(); err != nil {
 		return nil, err
@@ -95,7 +100,70 @@ func (c *CardClient) Named(ctx context.Context, options CardNamedRequest) (*Card
 	options.addToQuery(query)
 	req.URL.RawQuery = query.Encode()
 
-	err = doRequest(c.client, req, &card)
+	err = doRequest(c.client, c.clock, req, &card)
+	if err != nil {
+		if options.Fuzzy != "" && errors.Is(err, ErrAmbiguous) {
+			return nil, &AmbiguousNameError{
+				Query:      options.Fuzzy,
+				Candidates: c.candidates(ctx, options.Fuzzy),
+				Err:        err,
+			}
+		}
+
+		return nil, fmt.Errorf("failed to perform HTTP request: %w", err)
+	}
+
+	return &card, nil
+}
+
+// maxCandidates is the most candidate names an AmbiguousNameError carries.
+const maxCandidates = 20
+
+// candidates looks up the names of cards an ambiguous fuzzy name could
+// refer to, first with Autocomplete and then with a name search.
+// Errors are ignored, as the names are only suggestions.
+func (c *CardClient) candidates(ctx context.Context, fuzzy string) []string {
+	// The caller's ResponseMeta describes the failed lookup, not these.
+	ctx = withoutResponseMeta(ctx)
+
+	if names, err := c.Autocomplete(ctx, fuzzy); err == nil && len(names) > 0 {
+		return names
+	}
+
+	pager, err := c.Search(ctx, fuzzy, CardSearchOptions{})
+	if err != nil {
+		return nil
+	}
+
+	cards, err := pager.Next(ctx)
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+
+	for _, card := range cards {
+		if len(names) == maxCandidates {
+			break
+		}
+
+		names = append(names, card.Name)
+	}
+
+	return names
+}
+
+// ByID fetches a single card by its Scryfall ID.
+func (c *CardClient) ByID(ctx context.Context, id string) (*Card, error) {
+	// https://scryfall.com/docs/api/cards/id
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.scryfall.com/cards/"+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	var card Card
+
+	err = doRequest(c.client, c.clock, req, &card)
 	if err != nil {
 		return nil, fmt.Errorf("failed to perform HTTP request: %w", err)
 	}
@@ -115,6 +183,7 @@ type listContainer[T any] struct {
 // CardSearchPager allows reading through several pages of card search results.
 type CardSearchPager struct {
 	client   *http.Client
+	clock    Clock
 	nextPage string
 	done     bool
 }
@@ -140,14 +209,9 @@ func (c *CardSearchPager) Next(ctx context.Context) ([]Card, error) {
 
 	var lst listContainer[Card]
 
-	err = doRequest(c.client, req, &lst)
+	err = doRequest(c.client, c.clock, req, &lst)
 	if err != nil {
-		apiErr := APIError{}
-		if errors.As(err, &apiErr) {
-			if apiErr.Status == 422 {
-				return nil, ErrNoBackFace
-			}
-		}
+		return nil, fmt.Errorf("failed to perform HTTP request: %w", err)
 	}
 
 	c.nextPage = lst.NextPage
@@ -224,6 +288,7 @@ func (c *CardClient) Search(ctx context.Context, query string, opts CardSearchOp
 
 	pager := &CardSearchPager{
 		client:   c.client,
+		clock:    c.clock,
 		nextPage: req.URL.String(),
 	}
 
@@ -248,7 +313,7 @@ func (c *CardClient) Autocomplete(ctx context.Context, query string) ([]string,
 
 	var autocomplete autocompleteResponse
 
-	err = doRequest(c.client, req, &autocomplete)
+	err = doRequest(c.client, c.clock, req, &autocomplete)
 	if err != nil {
 		return nil, fmt.Errorf("failed to perform HTTP request: %w", err)
 	}
@@ -286,7 +351,7 @@ func (c *CardClient) Random(ctx context.Context, query string, opts RandomCardOp
 
 	var card Card
 
-	err = doRequest(c.client, req, &card)
+	err = doRequest(c.client, c.clock, req, &card)
 	if err != nil {
 		return nil, fmt.Errorf("failed to perform HTTP request: %w", err)
 	}
@@ -296,35 +361,67 @@ func (c *CardClient) Random(ctx context.Context, query string, opts RandomCardOp
 
 // CardIdentifier allows providing one of several identifiers for a card.
 // At least one identifier is required to function.
+//
+// Scryfall accepts the following combinations: ID, MTGOID, MultiverseID,
+// OracleID, IllustrationID, Name, Name and Set, or Set and CollectorNumber.
 type CardIdentifier struct {
-	ID              string `json:"id"`
-	MTGOID          int    `json:"mtgo_id"`
-	MultiverseID    int    `json:"multiverse_id"`
-	OracleID        string `json:"oracle_id"`
-	IllustrationID  string `json:"illustration_id"`
-	Name            string `json:"name"`
-	Set             string `json:"set"`
-	CollectorNumber string `json:"collector_number"`
+	ID              string `json:"id,omitempty"`
+	MTGOID          int    `json:"mtgo_id,omitempty"`
+	MultiverseID    int    `json:"multiverse_id,omitempty"`
+	OracleID        string `json:"oracle_id,omitempty"`
+	IllustrationID  string `json:"illustration_id,omitempty"`
+	Name            string `json:"name,omitempty"`
+	Set             string `json:"set,omitempty"`
+	CollectorNumber string `json:"collector_number,omitempty"`
 }
 
 type collectionRequest struct {
 	Identifiers []CardIdentifier `json:"identifiers"`
 }
 
+// maxCollectionIdentifiers is the maximum number of identifiers Scryfall
+// accepts in a single collection request.
+const maxCollectionIdentifiers = 75
+
+// Collection fetches the cards matching each of the identifiers.
+// Identifiers that do not match a card are left out of the result, so the
+// result may be shorter than identifiers.
+//
+// Scryfall accepts at most 75 identifiers per request, so larger lists are
+// split into several requests.
 func (c *CardClient) Collection(ctx context.Context, identifiers []CardIdentifier) ([]Card, error) {
+	var cards []Card
+
+	for start := 0; start < len(identifiers); start += maxCollectionIdentifiers {
+		end := min(start+maxCollectionIdentifiers, len(identifiers))
+
+		batch, err := c.collection(ctx, identifiers[start:end])
+		if err != nil {
+			return nil, err
+		}
+
+		cards = append(cards, batch...)
+	}
+
+	return cards, nil
+}
+
+func (c *CardClient) collection(ctx context.Context, identifiers []CardIdentifier) ([]Card, error) {
 	marshalled, err := json.Marshal(collectionRequest{Identifiers: identifiers})
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal identifiers: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.scryfall.com/cards/random", bytes.NewBuffer(marshalled))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.scryfall.com/cards/collection", bytes.NewBuffer(marshalled))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 
+	req.Header.Set("Content-Type", "application/json")
+
 	var list listContainer[Card]
 
-	err = doRequest(c.client, req, &list)
+	err = doRequest(c.client, c.clock, req, &list)
 	if err != nil {
 		return nil, fmt.Errorf("failed to perform HTTP request: %w", err)
 	}
@@ -0,0 +1,53 @@
+package gofall
+
+import (
+	"regexp"
+	"strings"
+)
+
+// AllCardTypes returns every card type, e.g. Creature or Instant.
+func AllCardTypes() []string {
+	return []string{
+		"Artifact", "Battle", "Creature", "Enchantment", "Instant",
+		"Kindred", "Land", "Planeswalker", "Sorcery", "Tribal",
+	}
+}
+
+// manaSymbolPattern matches a single mana symbol such as {R} or {W/U}.
+var manaSymbolPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+// ManaSymbols returns the symbols in a mana cost without their braces,
+// e.g. ["2", "W/U", "R"] for "{2}{W/U}{R}".
+func ManaSymbols(manaCost string) []string {
+	var symbols []string
+
+	for _, match := range manaSymbolPattern.FindAllStringSubmatch(manaCost, -1) {
+		symbols = append(symbols, match[1])
+	}
+
+	return symbols
+}
+
+// FrontManaCost returns the mana cost of the card's front face, which is
+// the cost it is cast for and counts towards deck building rules.
+// Transforming and modal double-faced cards only have mana costs on their
+// faces, and split and adventure cards have both costs in ManaCost.
+func (c *Card) FrontManaCost() string {
+	if c.ManaCost == "" && len(c.CardFaces) > 0 {
+		return c.CardFaces[0].ManaCost
+	}
+
+	front, _, _ := strings.Cut(c.ManaCost, " // ")
+
+	return front
+}
+
+// FrontOracleText returns the Oracle text of the card's front face.
+// Multi-faced cards only have Oracle text on their faces.
+func (c *Card) FrontOracleText() string {
+	if c.OracleText == "" && len(c.CardFaces) > 0 {
+		return c.CardFaces[0].OracleText
+	}
+
+	return c.OracleText
+}
@@ -0,0 +1,74 @@
+package gofall_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SethCurry/gofall"
+)
+
+func Test_Card_FrontFace(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		card       gofall.Card
+		manaCost   string
+		oracleText string
+	}{
+		{
+			name:       "single-faced",
+			card:       gofall.Card{ManaCost: "{R}", OracleText: "Lightning Bolt deals 3 damage to any target."},
+			manaCost:   "{R}",
+			oracleText: "Lightning Bolt deals 3 damage to any target.",
+		},
+		{
+			name: "adventure",
+			card: gofall.Card{
+				ManaCost: "{4}{U} // {1}{U}",
+				CardFaces: []gofall.CardFace{
+					{ManaCost: "{4}{U}", OracleText: "Flying"},
+					{ManaCost: "{1}{U}", OracleText: "Target creature gets -4/-0 until end of turn."},
+				},
+			},
+			manaCost:   "{4}{U}",
+			oracleText: "Flying",
+		},
+		{
+			name: "transform",
+			card: gofall.Card{
+				CardFaces: []gofall.CardFace{
+					{ManaCost: "{U}", OracleText: "At the beginning of your upkeep, look at the top card of your library."},
+					{OracleText: "Flying"},
+				},
+			},
+			manaCost:   "{U}",
+			oracleText: "At the beginning of your upkeep, look at the top card of your library.",
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := v.card.FrontManaCost(); got != v.manaCost {
+				t.Errorf("unexpected mana cost: got %q, want %q", got, v.manaCost)
+			}
+
+			if got := v.card.FrontOracleText(); got != v.oracleText {
+				t.Errorf("unexpected Oracle text: got %q, want %q", got, v.oracleText)
+			}
+		})
+	}
+}
+
+func Test_ManaSymbols(t *testing.T) {
+	t.Parallel()
+
+	got := gofall.ManaSymbols("{2}{W/U}{R}")
+	want := []string{"2", "W/U", "R"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected symbols: got %v, want %v", got, want)
+	}
+}
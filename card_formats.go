@@ -0,0 +1,209 @@
+package gofall
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ImageFace selects which face of a card an image is of.
+type ImageFace string
+
+const (
+	// ImageFaceFront is the front face, and the only face of single-faced cards.
+	ImageFaceFront ImageFace = ""
+
+	// ImageFaceBack is the back face of a double-faced card.  Requesting it
+	// for a card without a back face returns ErrNoBackFace.
+	ImageFaceBack ImageFace = "back"
+)
+
+// responseFormat is the format parameter accepted by the card endpoints.
+type responseFormat string
+
+const (
+	formatImage responseFormat = "image"
+	formatText  responseFormat = "text"
+)
+
+// getFormatted requests the endpoint with format set, returning the
+// response for the caller to read and close.
+func (c *CardClient) getFormatted(
+	ctx context.Context,
+	endpoint string,
+	query url.Values,
+	format responseFormat,
+) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	query.Set("format", string(format))
+	req.URL.RawQuery = query.Encode()
+
+	if format == formatImage {
+		return c.followImageRedirect(req)
+	}
+
+	resp, err := doRawRequest(c.client, c.clock, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to perform HTTP request: %w", err)
+	}
+
+	return resp, nil
+}
+
+// followImageRedirect performs an image request.  Scryfall responds with
+// a 302 redirect to the image on its CDN, which is followed here rather
+// than by the HTTP client so it works even if the client was configured
+// not to follow redirects, and so the download is not counted against
+// the API's rate limit.
+func (c *CardClient) followImageRedirect(req *http.Request) (*http.Response, error) {
+	noRedirects := *c.client
+	noRedirects.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	resp, err := noRedirects.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to do request: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp, nil
+	case http.StatusFound, http.StatusMovedPermanently, http.StatusSeeOther, http.StatusTemporaryRedirect:
+	default:
+		defer resp.Body.Close()
+
+		return nil, fmt.Errorf("failed to perform HTTP request: %w", decodeAPIError(resp))
+	}
+
+	resp.Body.Close()
+
+	location, err := resp.Location()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image redirect: %w", err)
+	}
+
+	imageReq, err := http.NewRequestWithContext(req.Context(), http.MethodGet, location.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	imageResp, err := doRawRequest(c.images, c.clock, imageReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download image: %w", err)
+	}
+
+	return imageResp, nil
+}
+
+// getImage requests an image, returning its body and content type.
+func (c *CardClient) getImage(
+	ctx context.Context,
+	endpoint string,
+	query url.Values,
+	imageType ImageType,
+	face ImageFace,
+) (io.ReadCloser, string, error) {
+	query.Set("version", string(imageType))
+
+	if face == ImageFaceBack {
+		query.Set("face", string(face))
+	} else {
+		query.Del("face")
+	}
+
+	resp, err := c.getFormatted(ctx, endpoint, query, formatImage)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return resp.Body, resp.Header.Get("Content-Type"), nil
+}
+
+// getText requests the plain-text rendering of a card.
+func (c *CardClient) getText(ctx context.Context, endpoint string, query url.Values) (string, error) {
+	resp, err := c.getFormatted(ctx, endpoint, query, formatText)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return string(body), nil
+}
+
+// NamedImage fetches the image of a card by its name, as with Named.  The
+// Version and Face of options are ignored in favor of imageType and face.
+// It returns the image and its content type, e.g. "image/jpeg".  The caller
+// must close the returned reader.
+func (c *CardClient) NamedImage(
+	ctx context.Context,
+	options CardNamedRequest,
+	imageType ImageType,
+	face ImageFace,
+) (io.ReadCloser, string, error) {
+	if err := options.validate(); err != nil {
+		return nil, "", err
+	}
+
+	query := url.Values{}
+	options.addToQuery(query)
+
+	return c.getImage(ctx, "https://api.scryfall.com/cards/named", query, imageType, face)
+}
+
+// NamedText fetches the plain-text rendering of a card by its name,
+// as with Named.
+func (c *CardClient) NamedText(ctx context.Context, options CardNamedRequest) (string, error) {
+	if err := options.validate(); err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	options.addToQuery(query)
+
+	return c.getText(ctx, "https://api.scryfall.com/cards/named", query)
+}
+
+// RandomImage fetches the image of a random card matching the query, as
+// with Random.  The caller must close the returned reader.
+func (c *CardClient) RandomImage(
+	ctx context.Context,
+	query string,
+	imageType ImageType,
+	face ImageFace,
+) (io.ReadCloser, string, error) {
+	return c.getImage(ctx, "https://api.scryfall.com/cards/random", url.Values{"q": {query}}, imageType, face)
+}
+
+// RandomText fetches the plain-text rendering of a random card matching
+// the query, as with Random.
+func (c *CardClient) RandomText(ctx context.Context, query string) (string, error) {
+	return c.getText(ctx, "https://api.scryfall.com/cards/random", url.Values{"q": {query}})
+}
+
+// ByIDImage fetches the image of a card by its Scryfall ID.  The caller
+// must close the returned reader.
+func (c *CardClient) ByIDImage(
+	ctx context.Context,
+	id string,
+	imageType ImageType,
+	face ImageFace,
+) (io.ReadCloser, string, error) {
+	return c.getImage(ctx, "https://api.scryfall.com/cards/"+url.PathEscape(id), url.Values{}, imageType, face)
+}
+
+// ByIDText fetches the plain-text rendering of a card by its Scryfall ID.
+func (c *CardClient) ByIDText(ctx context.Context, id string) (string, error) {
+	return c.getText(ctx, "https://api.scryfall.com/cards/"+url.PathEscape(id), url.Values{})
+}
@@ -0,0 +1,147 @@
+package gofall_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/SethCurry/gofall"
+)
+
+// rewriteTransport sends every request to a test server,
+// whatever host it was made for.
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (r rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = r.target.Scheme
+	req.URL.Host = r.target.Host
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newTestClient(t *testing.T, handler http.Handler) *gofall.Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	target, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("failed to parse test server URL: %v", err)
+	}
+
+	return gofall.NewClient(&http.Client{Transport: rewriteTransport{target: target}})
+}
+
+func Test_CardClient_Formats(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/cards/named", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		if query.Get("face") == "back" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = io.WriteString(w, `{"object":"error","status":422,"code":"no_back_face","details":"no back face"}`)
+
+			return
+		}
+
+		switch query.Get("format") {
+		case "text":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = io.WriteString(w, "Lightning Bolt {R}\nInstant\nLightning Bolt deals 3 damage to any target.")
+		case "image":
+			http.Redirect(w, r, "https://cards.scryfall.io/"+query.Get("version")+"/bolt.jpg?123", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	mux.HandleFunc("/large/bolt.jpg", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "image/jpeg")
+		_, _ = io.WriteString(w, "jpeg bytes")
+	})
+
+	client := newTestClient(t, mux)
+	request := gofall.CardNamedRequest{Exact: "Lightning Bolt"}
+
+	text, err := client.Card.NamedText(context.Background(), request)
+	if err != nil {
+		t.Fatalf("failed to get card text: %v", err)
+	}
+
+	if text != "Lightning Bolt {R}\nInstant\nLightning Bolt deals 3 damage to any target." {
+		t.Errorf("unexpected card text: %q", text)
+	}
+
+	image, contentType, err := client.Card.NamedImage(context.Background(), request, gofall.ImageTypeLarge, gofall.ImageFaceFront)
+	if err != nil {
+		t.Fatalf("failed to get card image: %v", err)
+	}
+
+	body, _ := io.ReadAll(image)
+	image.Close()
+
+	if string(body) != "jpeg bytes" || contentType != "image/jpeg" {
+		t.Errorf("unexpected image %q with content type %q", body, contentType)
+	}
+
+	_, _, err = client.Card.NamedImage(context.Background(), request, gofall.ImageTypeLarge, gofall.ImageFaceBack)
+	if !errors.Is(err, gofall.ErrNoBackFace) {
+		t.Errorf("expected ErrNoBackFace, got %v", err)
+	}
+}
+
+func Test_CardClient_ImageStreamed(t *testing.T) {
+	t.Parallel()
+
+	finish := make(chan struct{})
+	defer close(finish)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/cards/random", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "https://cards.scryfall.io/large/slow.jpg", http.StatusFound)
+	})
+
+	mux.HandleFunc("/large/slow.jpg", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "image/jpeg")
+		_, _ = io.WriteString(w, "first")
+		w.(http.Flusher).Flush()
+
+		<-finish
+	})
+
+	client := newTestClient(t, mux)
+	result := make(chan error, 1)
+
+	go func() {
+		image, _, err := client.Card.RandomImage(context.Background(), "", gofall.ImageTypeLarge, gofall.ImageFaceFront)
+		if err == nil {
+			image.Close()
+		}
+
+		result <- err
+	}()
+
+	// The image must be returned while it is still downloading,
+	// rather than read fully first.
+	select {
+	case err := <-result:
+		if err != nil {
+			t.Errorf("failed to get card image: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("expected the image to be streamed")
+	}
+}
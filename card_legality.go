@@ -1,5 +1,7 @@
 package gofall
 
+import "fmt"
+
 // CardLegality stores the legality of a card in various formats.
 type CardLegality struct {
 	Standard        Legality `json:"standard"`
@@ -24,3 +26,89 @@ type CardLegality struct {
 	PreModern       Legality `json:"premodern"`
 	PrEDH           Legality `json:"predh"`
 }
+
+// field returns a pointer to the field of c that stores the legality
+// for format, or nil if the format is not known.
+func (c *CardLegality) field(format Format) *Legality {
+	switch format {
+	case FormatStandard:
+		return &c.Standard
+	case FormatFuture:
+		return &c.Future
+	case FormatHistoric:
+		return &c.Historic
+	case FormatGladiator:
+		return &c.Gladiator
+	case FormatPioneer:
+		return &c.Pioneer
+	case FormatExplorer:
+		return &c.Explorer
+	case FormatModern:
+		return &c.Modern
+	case FormatLegacy:
+		return &c.Legacy
+	case FormatPauper:
+		return &c.Pauper
+	case FormatVintage:
+		return &c.Vintage
+	case FormatPenny:
+		return &c.Penny
+	case FormatCommander:
+		return &c.Commander
+	case FormatOathbreaker:
+		return &c.Oathbreaker
+	case FormatBrawl:
+		return &c.Brawl
+	case FormatHistoricBrawl:
+		return &c.HistoricBrawl
+	case FormatAlchemy:
+		return &c.Alchemy
+	case FormatPauperCommander:
+		return &c.PauperCommander
+	case FormatDuel:
+		return &c.Duel
+	case FormatOldSchool:
+		return &c.OldSchool
+	case FormatPreModern:
+		return &c.PreModern
+	case FormatPrEDH:
+		return &c.PrEDH
+	default:
+		return nil
+	}
+}
+
+// Get returns the legality of the card in the given format.
+// It returns an empty Legality if the format is not known.
+func (c CardLegality) Get(format Format) Legality {
+	field := c.field(format)
+	if field == nil {
+		return Legality("")
+	}
+
+	return *field
+}
+
+// Set sets the legality of the card in the given format.
+// It returns ErrUnknownFormat if the format is not known.
+func (c *CardLegality) Set(format Format, legality Legality) error {
+	field := c.field(format)
+	if field == nil {
+		return fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+
+	*field = legality
+
+	return nil
+}
+
+// Formats returns the legality of the card in every known format.
+func (c CardLegality) Formats() map[Format]Legality {
+	formats := make(map[Format]Legality, len(AllFormats()))
+
+	for _, format := range AllFormats() {
+		formats[format] = c.Get(format)
+	}
+
+	return formats
+}
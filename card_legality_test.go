@@ -0,0 +1,69 @@
+package gofall_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/SethCurry/gofall"
+)
+
+func Test_CardLegality_GetSet(t *testing.T) {
+	t.Parallel()
+
+	var legality gofall.CardLegality
+
+	for _, format := range gofall.AllFormats() {
+		if err := legality.Set(format, gofall.LegalityRestricted); err != nil {
+			t.Fatalf("failed to set legality for %s: %v", format, err)
+		}
+
+		if got := legality.Get(format); got != gofall.LegalityRestricted {
+			t.Errorf("unexpected legality for %s: %v", format, got)
+		}
+	}
+
+	if legality.Vintage != gofall.LegalityRestricted {
+		t.Errorf("Set did not update the Vintage field")
+	}
+
+	if err := legality.Set(gofall.Format("unknown"), gofall.LegalityLegal); !errors.Is(err, gofall.ErrUnknownFormat) {
+		t.Errorf("expected ErrUnknownFormat, got %v", err)
+	}
+
+	if got := legality.Get(gofall.Format("unknown")); got != "" {
+		t.Errorf("expected empty legality for unknown format, got %v", got)
+	}
+}
+
+func Test_CardLegality_Formats(t *testing.T) {
+	t.Parallel()
+
+	legality := gofall.CardLegality{Modern: gofall.LegalityBanned}
+
+	formats := legality.Formats()
+	if len(formats) != len(gofall.AllFormats()) {
+		t.Errorf("expected %d formats, got %d", len(gofall.AllFormats()), len(formats))
+	}
+
+	if formats[gofall.FormatModern] != gofall.LegalityBanned {
+		t.Errorf("unexpected legality for modern: %v", formats[gofall.FormatModern])
+	}
+}
+
+func Test_Format_UnmarshalText(t *testing.T) {
+	t.Parallel()
+
+	var format gofall.Format
+
+	if err := format.UnmarshalText([]byte("paupercommander")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if format != gofall.FormatPauperCommander {
+		t.Errorf("unexpected format: %v", format)
+	}
+
+	if err := format.UnmarshalText([]byte("unknown")); !errors.Is(err, gofall.ErrUnknownFormat) {
+		t.Errorf("expected ErrUnknownFormat, got %v", err)
+	}
+}
@@ -0,0 +1,396 @@
+// Package cassette provides an http.RoundTripper that records requests
+// to Scryfall in a file and replays them later, so tests can run against
+// real responses without depending on the network.
+//
+// A Cassette is meant to be used as the Transport of the client passed to
+// gofall.NewClient, underneath the client's rate limiting and retries:
+//
+//	tape, err := cassette.Open("testdata/named.json", cassette.Options{})
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//
+//	client := gofall.NewClient(&http.Client{Transport: tape})
+package cassette
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"unicode/utf8"
+)
+
+// ErrUnrecorded is returned by a Cassette in ModeStrict for requests
+// it has no recording of.
+var ErrUnrecorded = errors.New("request was not recorded")
+
+// Mode controls when a Cassette sends requests to the network.
+type Mode int
+
+const (
+	// ModeReplay replays recorded requests, and records requests it
+	// has no recording of.  It is the default.
+	ModeReplay Mode = iota
+
+	// ModeRecord sends every request and records it, replacing
+	// anything previously in the cassette.  Use it to refresh
+	// recordings after the API changes.
+	ModeRecord
+
+	// ModeStrict only replays recorded requests, and fails with
+	// ErrUnrecorded for any others.  It never uses the network.
+	ModeStrict
+)
+
+// Options configures a Cassette.
+type Options struct {
+	// Mode controls when requests are sent to the network.
+	Mode Mode
+
+	// Transport sends requests that are being recorded.
+	// Defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+
+	// Redact is called with every interaction before it is recorded,
+	// and with every incoming request before it is matched against the
+	// recordings, so it can remove secrets or unstable values.  Changes
+	// it makes to requests affect matching, so they should be the same
+	// for every request.  See RedactHeaders.
+	Redact func(*Interaction)
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request.  Requests are matched by their method,
+// URL and body.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body,omitempty"`
+}
+
+// Body is the body of a recorded request or response.  It is stored as
+// a string when it is valid UTF-8, so recordings of JSON stay readable,
+// and as base64 otherwise.
+type Body []byte
+
+// MarshalJSON implements the json.Marshaler interface.
+func (b Body) MarshalJSON() ([]byte, error) {
+	var value any = string(b)
+
+	if !utf8.Valid(b) {
+		value = map[string]string{"base64": base64.StdEncoding.EncodeToString(b)}
+	}
+
+	marshalled, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal body: %w", err)
+	}
+
+	return marshalled, nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var text string
+
+	if err := json.Unmarshal(data, &text); err == nil {
+		*b = Body(text)
+
+		return nil
+	}
+
+	var encoded struct {
+		Base64 string `json:"base64"`
+	}
+
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return fmt.Errorf("failed to unmarshal body: %w", err)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded.Base64)
+	if err != nil {
+		return fmt.Errorf("failed to decode body: %w", err)
+	}
+
+	*b = decoded
+
+	return nil
+}
+
+// RedactHeaders returns a Redact function that removes the given headers
+// from requests and responses.
+func RedactHeaders(names ...string) func(*Interaction) {
+	return func(interaction *Interaction) {
+		for _, name := range names {
+			interaction.Request.Header.Del(name)
+			interaction.Response.Header.Del(name)
+		}
+	}
+}
+
+// Open loads the cassette at path.  The file does not need to exist yet;
+// it is created when the first request is recorded.
+func Open(path string, opts Options) (*Cassette, error) {
+	if opts.Transport == nil {
+		opts.Transport = http.DefaultTransport
+	}
+
+	cassette := &Cassette{path: path, opts: opts}
+
+	if opts.Mode == ModeRecord {
+		return cassette, nil
+	}
+
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && opts.Mode != ModeStrict {
+		return cassette, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+
+	if err := json.Unmarshal(contents, &cassette.interactions); err != nil {
+		return nil, fmt.Errorf("failed to decode cassette %s: %w", path, err)
+	}
+
+	cassette.replayed = make([]bool, len(cassette.interactions))
+
+	return cassette, nil
+}
+
+// Cassette is an http.RoundTripper that replays recorded interactions,
+// recording new ones as its Mode allows.  It is safe for concurrent use.
+//
+// Identical requests are replayed in the order they were recorded.  Once
+// every recording of a request has been replayed, further identical
+// requests are recorded, or in ModeStrict get the last recording again.
+type Cassette struct {
+	path string
+	opts Options
+
+	lock         sync.Mutex
+	interactions []Interaction
+	replayed     []bool
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	request, send, err := newRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.opts.Mode != ModeRecord {
+		if resp, ok := c.replay(req, request); ok {
+			return resp, nil
+		}
+
+		if c.opts.Mode == ModeStrict {
+			return nil, fmt.Errorf("%w: %s %s", ErrUnrecorded, req.Method, req.URL)
+		}
+	}
+
+	return c.record(send, request)
+}
+
+// Interactions returns a copy of the cassette's interactions.
+func (c *Cassette) Interactions() []Interaction {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return append([]Interaction{}, c.interactions...)
+}
+
+// newRequest reads the request into a Request.  As that consumes its
+// body, it also returns a copy of req that can still be sent.
+func newRequest(req *http.Request) (Request, *http.Request, error) {
+	request := Request{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: req.Header.Clone(),
+	}
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return request, req, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+
+	if err != nil {
+		return request, nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	request.Body = body
+
+	send := req.Clone(req.Context())
+	send.Body = io.NopCloser(bytes.NewReader(body))
+
+	return request, send, nil
+}
+
+func (c *Cassette) redact(interaction *Interaction) {
+	if interaction.Request.Header == nil {
+		interaction.Request.Header = http.Header{}
+	}
+
+	if interaction.Response.Header == nil {
+		interaction.Response.Header = http.Header{}
+	}
+
+	if c.opts.Redact != nil {
+		c.opts.Redact(interaction)
+	}
+}
+
+func matches(recorded, request Request) bool {
+	return recorded.Method == request.Method &&
+		recorded.URL == request.URL &&
+		bytes.Equal(recorded.Body, request.Body)
+}
+
+// replay finds the recorded response to the request, if there is one.
+func (c *Cassette) replay(req *http.Request, request Request) (*http.Response, bool) {
+	incoming := Interaction{Request: request}
+	c.redact(&incoming)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	last := -1
+
+	for i, interaction := range c.interactions {
+		if !matches(interaction.Request, incoming.Request) {
+			continue
+		}
+
+		last = i
+
+		if !c.replayed[i] {
+			break
+		}
+	}
+
+	if last == -1 {
+		return nil, false
+	}
+
+	// Outside of ModeStrict, further identical requests are recorded.
+	if c.replayed[last] && c.opts.Mode != ModeStrict {
+		return nil, false
+	}
+
+	c.replayed[last] = true
+
+	return c.interactions[last].Response.response(req), true
+}
+
+func (r Response) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// record sends the request and saves the interaction.
+func (c *Cassette) record(req *http.Request, request Request) (*http.Response, error) {
+	resp, err := c.opts.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	interaction := Interaction{
+		Request: request,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       body,
+		},
+	}
+
+	c.redact(&interaction)
+
+	if err := c.save(interaction); err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+
+	return resp, nil
+}
+
+// save adds the interaction to the cassette and writes it to disk.
+// The file is replaced atomically so a failed write cannot corrupt it.
+func (c *Cassette) save(interaction Interaction) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.interactions = append(c.interactions, interaction)
+	c.replayed = append(c.replayed, true)
+
+	contents, err := json.MarshalIndent(c.interactions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), ".cassette-*")
+	if err != nil {
+		return fmt.Errorf("failed to create cassette: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(contents, '\n')); err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+
+	return nil
+}
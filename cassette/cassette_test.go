@@ -0,0 +1,192 @@
+package cassette_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SethCurry/gofall"
+	"github.com/SethCurry/gofall/cassette"
+	"github.com/SethCurry/gofall/scryfalltest"
+)
+
+func newServer(t *testing.T) *scryfalltest.Server {
+	t.Helper()
+
+	cards, err := scryfalltest.LoadCards("../test/cards.json")
+	if err != nil {
+		t.Fatalf("failed to load cards: %v", err)
+	}
+
+	server := scryfalltest.NewServer(scryfalltest.Options{Cards: cards})
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func open(t *testing.T, path string, opts cassette.Options) *gofall.Client {
+	t.Helper()
+
+	tape, err := cassette.Open(path, opts)
+	if err != nil {
+		t.Fatalf("failed to open cassette: %v", err)
+	}
+
+	return gofall.NewClient(&http.Client{Transport: tape})
+}
+
+func Test_Cassette_RecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "testdata", "cassette.json")
+	server := newServer(t)
+
+	recording := open(t, path, cassette.Options{Transport: server.Transport()})
+
+	recorded, err := recording.Card.Named(ctx, gofall.CardNamedRequest{Exact: "Fury Sliver"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	collection, err := recording.Card.Collection(ctx, []gofall.CardIdentifier{{Name: "Web"}})
+	if err != nil || len(collection) != 1 {
+		t.Fatalf("unexpected collection %v: %v", collection, err)
+	}
+
+	server.Close()
+
+	replaying := open(t, path, cassette.Options{Mode: cassette.ModeStrict})
+
+	replayed, err := replaying.Card.Named(ctx, gofall.CardNamedRequest{Exact: "Fury Sliver"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if replayed.ID != recorded.ID {
+		t.Errorf("expected the recorded card, got %s", replayed.Name)
+	}
+
+	if _, err := replaying.Card.Collection(ctx, []gofall.CardIdentifier{{Name: "Web"}}); err != nil {
+		t.Errorf("expected the collection request to match on its body, got %v", err)
+	}
+
+	_, err = replaying.Card.Collection(ctx, []gofall.CardIdentifier{{Name: "Spirit"}})
+	if !errors.Is(err, cassette.ErrUnrecorded) {
+		t.Errorf("expected ErrUnrecorded for a different body, got %v", err)
+	}
+
+	_, err = replaying.Card.Named(ctx, gofall.CardNamedRequest{Exact: "Web"})
+	if !errors.Is(err, cassette.ErrUnrecorded) {
+		t.Errorf("expected ErrUnrecorded, got %v", err)
+	}
+}
+
+func Test_Cassette_ReplayOrder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	server := newServer(t)
+
+	// The first request fails and is retried by the caller.
+	server.RateLimitNext(1)
+
+	recording := open(t, path, cassette.Options{Transport: server.Transport()})
+
+	if _, err := recording.Card.ByID(ctx, "0000579f-7b35-4ed3-b44c-db2a538066fe"); !errors.Is(err, gofall.ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+
+	if _, err := recording.Card.ByID(ctx, "0000579f-7b35-4ed3-b44c-db2a538066fe"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	replaying := open(t, path, cassette.Options{Mode: cassette.ModeStrict})
+
+	if _, err := replaying.Card.ByID(ctx, "0000579f-7b35-4ed3-b44c-db2a538066fe"); !errors.Is(err, gofall.ErrRateLimited) {
+		t.Errorf("expected the first recording to be replayed first, got %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := replaying.Card.ByID(ctx, "0000579f-7b35-4ed3-b44c-db2a538066fe"); err != nil {
+			t.Errorf("expected the last recording to be replayed, got %v", err)
+		}
+	}
+}
+
+func Test_Cassette_Redact(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	server := newServer(t)
+
+	tape, err := cassette.Open(path, cassette.Options{
+		Transport: server.Transport(),
+		Redact:    cassette.RedactHeaders("Authorization", "Date"),
+	})
+	if err != nil {
+		t.Fatalf("failed to open cassette: %v", err)
+	}
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://api.scryfall.com/cdn/large/front/1.jpg", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+
+	resp, err := tape.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.Header.Get("Date") == "" {
+		t.Errorf("expected redaction to only affect the recording")
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read cassette: %v", err)
+	}
+
+	var interactions []cassette.Interaction
+
+	if err := json.Unmarshal(contents, &interactions); err != nil {
+		t.Fatalf("failed to decode cassette: %v", err)
+	}
+
+	if len(interactions) != 1 {
+		t.Fatalf("expected 1 interaction, got %d", len(interactions))
+	}
+
+	if interactions[0].Request.Header.Get("Authorization") != "" || interactions[0].Response.Header.Get("Date") != "" {
+		t.Errorf("expected headers to be redacted, got %v", interactions[0])
+	}
+
+	if interactions[0].Response.Header.Get("Content-Type") != "image/jpeg" {
+		t.Errorf("expected other headers to be kept, got %v", interactions[0].Response.Header)
+	}
+}
+
+func Test_Body_JSON(t *testing.T) {
+	t.Parallel()
+
+	for _, body := range []cassette.Body{cassette.Body(`{"name":"Web"}`), {0xff, 0xd8, 0xff, 0xe0}} {
+		marshalled, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("failed to marshal body: %v", err)
+		}
+
+		var got cassette.Body
+
+		if err := json.Unmarshal(marshalled, &got); err != nil {
+			t.Fatalf("failed to unmarshal %s: %v", marshalled, err)
+		}
+
+		if string(got) != string(body) {
+			t.Errorf("unexpected body: got %q, want %q", got, body)
+		}
+	}
+}
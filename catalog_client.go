@@ -0,0 +1,145 @@
+package gofall
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// CatalogName is the name of one of Scryfall's catalogs: lists of values
+// such as every card type or every creature type.
+type CatalogName string
+
+const (
+	// CatalogCardNames lists every card name.
+	CatalogCardNames CatalogName = "card-names"
+
+	// CatalogArtistNames lists every artist name.
+	CatalogArtistNames CatalogName = "artist-names"
+
+	// CatalogWordBank lists every English word of length 2 or more that appears in a card name.
+	CatalogWordBank CatalogName = "word-bank"
+
+	// CatalogSupertypes lists every supertype, e.g. Legendary.
+	CatalogSupertypes CatalogName = "supertypes"
+
+	// CatalogCardTypes lists every card type, e.g. Creature.
+	CatalogCardTypes CatalogName = "card-types"
+
+	// CatalogArtifactTypes lists every artifact type, e.g. Equipment.
+	CatalogArtifactTypes CatalogName = "artifact-types"
+
+	// CatalogBattleTypes lists every battle type, e.g. Siege.
+	CatalogBattleTypes CatalogName = "battle-types"
+
+	// CatalogCreatureTypes lists every creature type, e.g. Elf.
+	CatalogCreatureTypes CatalogName = "creature-types"
+
+	// CatalogEnchantmentTypes lists every enchantment type, e.g. Aura.
+	CatalogEnchantmentTypes CatalogName = "enchantment-types"
+
+	// CatalogLandTypes lists every land type, e.g. Forest.
+	CatalogLandTypes CatalogName = "land-types"
+
+	// CatalogPlaneswalkerTypes lists every planeswalker type, e.g. Jace.
+	CatalogPlaneswalkerTypes CatalogName = "planeswalker-types"
+
+	// CatalogSpellTypes lists every instant and sorcery type, e.g. Adventure.
+	CatalogSpellTypes CatalogName = "spell-types"
+
+	// CatalogPowers lists every power that appears on a card.
+	CatalogPowers CatalogName = "powers"
+
+	// CatalogToughnesses lists every toughness that appears on a card.
+	CatalogToughnesses CatalogName = "toughnesses"
+
+	// CatalogLoyalties lists every starting loyalty that appears on a card.
+	CatalogLoyalties CatalogName = "loyalties"
+
+	// CatalogWatermarks lists every watermark.
+	CatalogWatermarks CatalogName = "watermarks"
+
+	// CatalogKeywordAbilities lists every keyword ability, e.g. Flying.
+	CatalogKeywordAbilities CatalogName = "keyword-abilities"
+
+	// CatalogKeywordActions lists every keyword action, e.g. Scry.
+	CatalogKeywordActions CatalogName = "keyword-actions"
+
+	// CatalogAbilityWords lists every ability word, e.g. Landfall.
+	CatalogAbilityWords CatalogName = "ability-words"
+
+	// CatalogFlavorWords lists every flavor word.
+	CatalogFlavorWords CatalogName = "flavor-words"
+)
+
+// subtypeCatalogs are the catalogs that list subtypes.
+var subtypeCatalogs = []CatalogName{
+	CatalogArtifactTypes,
+	CatalogBattleTypes,
+	CatalogCreatureTypes,
+	CatalogEnchantmentTypes,
+	CatalogLandTypes,
+	CatalogPlaneswalkerTypes,
+	CatalogSpellTypes,
+}
+
+// CatalogClient contains methods for fetching Scryfall's catalogs.
+type CatalogClient struct {
+	client *http.Client
+	clock  Clock
+}
+
+type catalogResponse struct {
+	Object      Object   `json:"object"`
+	URI         string   `json:"uri"`
+	TotalValues int      `json:"total_values"`
+	Data        []string `json:"data"`
+}
+
+// Get fetches every value in the named catalog.
+func (c *CatalogClient) Get(ctx context.Context, name CatalogName) ([]string, error) {
+	// https://scryfall.com/docs/api/catalogs
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.scryfall.com/catalog/"+string(name), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	var catalog catalogResponse
+
+	err = doRequest(c.client, c.clock, req, &catalog)
+	if err != nil {
+		return nil, fmt.Errorf("failed to perform HTTP request: %w", err)
+	}
+
+	return catalog.Data, nil
+}
+
+// TypeCatalog fetches the supertype, card type and subtype catalogs,
+// for validating type lines with TypeLine.Validate.
+func (c *CatalogClient) TypeCatalog(ctx context.Context) (*TypeCatalog, error) {
+	var (
+		catalog TypeCatalog
+		err     error
+	)
+
+	catalog.Supertypes, err = c.Get(ctx, CatalogSupertypes)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog.CardTypes, err = c.Get(ctx, CatalogCardTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range subtypeCatalogs {
+		subtypes, err := c.Get(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		catalog.Subtypes = append(catalog.Subtypes, subtypes...)
+	}
+
+	return &catalog, nil
+}
@@ -0,0 +1,61 @@
+package gofall_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/SethCurry/gofall"
+)
+
+// catalogTransport answers requests with a handler instead of the network.
+type catalogTransport struct {
+	handler http.Handler
+}
+
+func (c catalogTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorder := httptest.NewRecorder()
+	c.handler.ServeHTTP(recorder, req)
+
+	return recorder.Result(), nil
+}
+
+func Test_CatalogClient_Get(t *testing.T) {
+	t.Parallel()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/catalog/supertypes" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"object":"error","status":404,"code":"not_found","details":"not found"}`)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{
+			"object": "catalog",
+			"uri": "https://api.scryfall.com/catalog/supertypes",
+			"total_values": 3,
+			"data": ["Basic", "Legendary", "Snow"]
+		}`)
+	})
+
+	client := gofall.NewClient(&http.Client{Transport: catalogTransport{handler: handler}})
+
+	got, err := client.Catalog.Get(context.Background(), gofall.CatalogSupertypes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"Basic", "Legendary", "Snow"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected catalog: got %v, want %v", got, want)
+	}
+
+	if _, err := client.Catalog.Get(context.Background(), gofall.CatalogWatermarks); err == nil {
+		t.Error("expected an error for a missing catalog")
+	}
+}
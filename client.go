@@ -2,18 +2,61 @@ package gofall
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 )
 
 // NewClient creates a new Client.
 func NewClient(startingClient *http.Client) *Client {
+	return NewClientWithOptions(ClientOptions{HTTPClient: startingClient})
+}
+
+// ClientOptions configures a Client.
+// The zero value is valid and uses the defaults described on each field.
+type ClientOptions struct {
+	// HTTPClient is the client requests are made with.  Its transport is
+	// wrapped with rate limiting and retries.  Defaults to a client with
+	// a 30 second timeout.
+	HTTPClient *http.Client
+
+	// Cache caches responses so repeated lookups do not use up the rate
+	// limit.  Optional; responses are not cached if it is nil.
+	Cache *Cache
+
+	// DisableCoalescing turns off merging of identical GET requests.
+	// By default, a GET made while an identical one is in flight waits
+	// for and shares its response instead of making another request.
+	DisableCoalescing bool
+
+	// Logger logs requests: failures at warning level, retries at info
+	// level and every request at debug level.  Optional; nothing is
+	// logged if it is nil.
+	Logger *slog.Logger
+
+	// Hooks receives events about requests for metrics and tracing.
+	// Optional; defaults to NoopHooks.
+	Hooks Hooks
+
+	// Clock is used by the rate limiter and retry backoff, and to measure
+	// ResponseMeta.Latency.  Defaults to SystemClock.  A Cache has its own
+	// clock, set in CacheOptions.
+	Clock Clock
+}
+
+// NewClientWithOptions creates a new Client configured by opts.
+func NewClientWithOptions(opts ClientOptions) *Client {
 	defaultMaxRetries := 5
 	defaultMaxRequests := 5
+	defaultMaxImageRequests := 10
 	defaultWindow := time.Second
 	defaultTimeoutSeconds := 30
 
+	startingClient := opts.HTTPClient
 	if startingClient == nil {
 		startingClient = &http.Client{
 			Timeout:       time.Second * time.Duration(defaultTimeoutSeconds),
@@ -23,16 +66,49 @@ func NewClient(startingClient *http.Client) *Client {
 		}
 	}
 
+	if opts.Clock == nil {
+		opts.Clock = SystemClock{}
+	}
+
 	transport := http.DefaultTransport
 	if startingClient.Transport != nil {
 		transport = startingClient.Transport
 	}
 
+	var wrapped http.RoundTripper = &roundTripper{
+		maxRetries: defaultMaxRetries,
+		limiter:    newRateLimiter(defaultWindow, defaultMaxRequests, opts.Clock),
+		inner:      transport,
+		observer:   newObserver(opts.Hooks, opts.Logger),
+		clock:      opts.Clock,
+	}
+
+	// The cache sits in front of the rate limiter so cached
+	// responses do not count against it.
+	if opts.Cache != nil {
+		wrapped = opts.Cache.wrap(wrapped)
+	}
+
+	if !opts.DisableCoalescing {
+		wrapped = newCoalescingTransport(wrapped)
+	}
+
 	httpClient := &http.Client{
+		Transport:     wrapped,
+		CheckRedirect: startingClient.CheckRedirect,
+		Jar:           startingClient.Jar,
+		Timeout:       startingClient.Timeout,
+	}
+
+	// Images are downloaded from Scryfall's CDN, which has limits of its
+	// own, so downloads skip the API's rate limiter, cache and coalescing.
+	images := &http.Client{
 		Transport: &roundTripper{
 			maxRetries: defaultMaxRetries,
-			limiter:    newRateLimiter(defaultWindow, defaultMaxRequests),
+			limiter:    newRateLimiter(defaultWindow, defaultMaxImageRequests, opts.Clock),
 			inner:      transport,
+			observer:   newObserver(opts.Hooks, opts.Logger),
+			clock:      opts.Clock,
 		},
 		CheckRedirect: startingClient.CheckRedirect,
 		Jar:           startingClient.Jar,
@@ -40,9 +116,10 @@ func NewClient(startingClient *http.Client) *Client {
 	}
 
 	return &Client{
-		Card:     &CardClient{client: httpClient},
-		BulkData: &BulkDataClient{client: httpClient},
-		Rulings:  &RulingClient{client: httpClient},
+		Card:     &CardClient{client: httpClient, clock: opts.Clock, images: images},
+		BulkData: &BulkDataClient{client: httpClient, clock: opts.Clock},
+		Rulings:  &RulingClient{client: httpClient, clock: opts.Clock},
+		Catalog:  &CatalogClient{client: httpClient, clock: opts.Clock},
 	}
 }
 
@@ -52,35 +129,100 @@ type Client struct {
 	Card     *CardClient
 	BulkData *BulkDataClient
 	Rulings  *RulingClient
+	Catalog  *CatalogClient
 }
 
-func doRequest(client *http.Client, req *http.Request, into interface{}) error {
-	resp, err := client.Do(req)
+func doRequest(client *http.Client, clock Clock, req *http.Request, into interface{}) error {
+	resp, err := doRawRequest(client, clock, req)
 	if err != nil {
-		return fmt.Errorf("failed to do request: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
 
-	decoder := json.NewDecoder(resp.Body)
+	if err := json.NewDecoder(resp.Body).Decode(into); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if list, ok := into.(listMeta); ok {
+		if meta := responseMetaFrom(req.Context()); meta != nil {
+			meta.recordList(list)
+		}
+	}
+
+	return nil
+}
+
+// doRawRequest performs the request and returns the response if it
+// succeeded, leaving the body for the caller to read and close.
+// Otherwise it returns the error from the API.
+func doRawRequest(client *http.Client, clock Clock, req *http.Request) (*http.Response, error) {
+	meta := responseMetaFrom(req.Context())
+	trace := &requestTrace{}
+	start := clock.Now()
+
+	if meta != nil {
+		req = req.WithContext(withRequestTrace(req.Context(), trace))
+	}
+
+	resp, err := client.Do(req)
+
+	if meta != nil {
+		meta.recordResponse(resp, trace, clock.Now().Sub(start))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to do request: %w", err)
+	}
 
 	if resp.StatusCode != http.StatusOK {
-		var apiErr APIError
-		apiErr.Status = resp.StatusCode
+		defer resp.Body.Close()
 
-		if err := decoder.Decode(&apiErr); err != nil {
-			return fmt.Errorf("failed to decode respone: %w", err)
+		err := decodeAPIError(resp)
+
+		var apiErr *APIError
+		if meta != nil && errors.As(err, &apiErr) {
+			meta.Warnings = append(meta.Warnings, apiErr.Warnings...)
 		}
 
-		if err := matchAPIError(&apiErr); err != nil {
-			return err
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// maxErrorBodySize is the most of an error response that is read.
+const maxErrorBodySize = 64 * 1024
+
+// maxErrorDetailsSize is the most of a non-JSON error body kept in APIError.Details.
+const maxErrorDetailsSize = 200
+
+// decodeAPIError reads the error from an unsuccessful response.
+// It always returns an *APIError, even if the body is not JSON, such
+// as an HTML error page from a CDN or proxy.
+func decodeAPIError(resp *http.Response) error {
+	apiErr := &APIError{}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+
+	if err := json.Unmarshal(body, apiErr); err != nil || apiErr.Code == "" {
+		// Not a Scryfall error object, so describe the response instead.
+		details := strings.TrimSpace(string(body))
+		if len(details) > maxErrorDetailsSize {
+			details = details[:maxErrorDetailsSize] + "..."
 		}
 
-		return &apiErr
+		apiErr = &APIError{
+			Code:    strings.ReplaceAll(strings.ToLower(http.StatusText(resp.StatusCode)), " ", "_"),
+			Details: details,
+		}
 	}
 
-	if err := decoder.Decode(into); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+	// The status of the response is more reliable than the one in the body.
+	apiErr.Status = resp.StatusCode
+
+	if resp.Request != nil {
+		apiErr.URL = resp.Request.URL.String()
 	}
 
-	return nil
+	return apiErr
 }
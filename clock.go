@@ -0,0 +1,82 @@
+package gofall
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock tells the time and waits, for the rate limiter, retry backoff
+// and cache expiry.  Replace the default SystemClock with a FakeClock to
+// test timing behavior without waiting for real time to pass.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// Sleep waits for d to pass.  It returns early with the context's
+	// error if ctx is done first.
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// SystemClock is a Clock using the real time.  It is the default.
+type SystemClock struct{}
+
+// Now implements the Clock interface.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Sleep implements the Clock interface.
+func (SystemClock) Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// NewFakeClock creates a FakeClock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// FakeClock is a Clock for tests that only moves when told to.  Sleep
+// advances it by the duration slept and returns immediately, so code
+// that backs off runs instantly while seeing time pass.
+//
+// It is safe to use from multiple goroutines.
+type FakeClock struct {
+	lock sync.Mutex
+	now  time.Time
+}
+
+// Now implements the Clock interface.
+func (f *FakeClock) Now() time.Time {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.now
+}
+
+// Sleep implements the Clock interface.
+func (f *FakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	f.Advance(d)
+
+	return nil
+}
+
+// Advance moves the clock forward by d.
+func (f *FakeClock) Advance(d time.Duration) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.now = f.now.Add(d)
+}
@@ -0,0 +1,145 @@
+package gofall
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// newCoalescingTransport creates a transport that merges identical GET
+// requests made while one is already in flight, so only one of them
+// reaches inner.
+func newCoalescingTransport(inner http.RoundTripper) *coalescingTransport {
+	return &coalescingTransport{
+		inner: inner,
+		calls: map[string]*coalescedCall{},
+	}
+}
+
+type coalescingTransport struct {
+	inner http.RoundTripper
+
+	lock  sync.Mutex
+	calls map[string]*coalescedCall
+}
+
+// coalescedCall is a request shared by several callers.
+type coalescedCall struct {
+	done chan struct{}
+
+	// cancel cancels the shared request.  It is called once every
+	// caller waiting on it has given up.
+	cancel  context.CancelFunc
+	waiters int
+
+	// trace is filled in by the shared request, and copied
+	// to each caller's trace once it is done.
+	trace requestTrace
+
+	resp *http.Response
+	body []byte
+	err  error
+}
+
+// response gives a caller its own copy of the shared response.
+func (c *coalescedCall) response(req *http.Request) *http.Response {
+	resp := *c.resp
+	resp.Header = c.resp.Header.Clone()
+	resp.Body = io.NopCloser(bytes.NewReader(c.body))
+	resp.ContentLength = int64(len(c.body))
+	resp.Request = req
+
+	return &resp
+}
+
+// coalescable reports whether identical requests can share a response.
+// Random cards are excluded, as each request should get a different card.
+func coalescable(req *http.Request) bool {
+	return req.Method == http.MethodGet &&
+		(req.Body == nil || req.Body == http.NoBody) &&
+		req.URL.Path != "/cards/random"
+}
+
+func (c *coalescingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !coalescable(req) {
+		return c.inner.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+
+	c.lock.Lock()
+
+	call, ok := c.calls[key]
+	if !ok {
+		// The shared request must outlive the caller that started it,
+		// in case that caller gives up while others are still waiting.
+		ctx, cancel := context.WithCancel(context.WithoutCancel(req.Context()))
+		call = &coalescedCall{done: make(chan struct{}), cancel: cancel}
+		c.calls[key] = call
+
+		go c.do(key, call, req.Clone(withRequestTrace(ctx, &call.trace)))
+	}
+
+	call.waiters++
+
+	c.lock.Unlock()
+
+	select {
+	case <-call.done:
+		*traceFrom(req.Context()) = call.trace
+
+		if call.err != nil {
+			return nil, call.err
+		}
+
+		return call.response(req), nil
+	case <-req.Context().Done():
+		c.lock.Lock()
+
+		call.waiters--
+		if call.waiters == 0 {
+			// Later callers must not join the cancelled request.
+			c.forget(key, call)
+			call.cancel()
+		}
+
+		c.lock.Unlock()
+
+		return nil, req.Context().Err()
+	}
+}
+
+// do performs the shared request and reads the whole body,
+// so every caller can be given a copy of it.
+func (c *coalescingTransport) do(key string, call *coalescedCall, req *http.Request) {
+	defer call.cancel()
+
+	resp, err := c.inner.RoundTrip(req)
+	if err == nil {
+		call.body, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if err != nil {
+			err = fmt.Errorf("failed to read response body: %w", err)
+		}
+	}
+
+	call.resp, call.err = resp, err
+
+	c.lock.Lock()
+	c.forget(key, call)
+	c.lock.Unlock()
+
+	close(call.done)
+}
+
+// forget removes call from the in-flight calls, unless it has already
+// been replaced by a newer call.  The lock must be held.
+func (c *coalescingTransport) forget(key string, call *coalescedCall) {
+	if c.calls[key] == call {
+		delete(c.calls, key)
+	}
+}
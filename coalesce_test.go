@@ -0,0 +1,241 @@
+package gofall
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockingTransport holds every request until release is closed.
+type blockingTransport struct {
+	requests  atomic.Int32
+	release   chan struct{}
+	cancelled chan struct{}
+}
+
+func (b *blockingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	b.requests.Add(1)
+
+	select {
+	case <-b.release:
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(`{"name":"Lightning Bolt"}`)),
+		}, nil
+	case <-req.Context().Done():
+		close(b.cancelled)
+
+		return nil, req.Context().Err()
+	}
+}
+
+// waitForWaiters waits until n callers are waiting on the request for url.
+func waitForWaiters(t *testing.T, transport *coalescingTransport, url string, n int) {
+	t.Helper()
+
+	for i := 0; i < 1000; i++ {
+		transport.lock.Lock()
+		call, ok := transport.calls[url]
+		waiting := ok && call.waiters == n
+		transport.lock.Unlock()
+
+		if waiting {
+			return
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatalf("timed out waiting for %d waiters", n)
+}
+
+func Test_CoalescingTransport(t *testing.T) {
+	t.Parallel()
+
+	inner := &blockingTransport{release: make(chan struct{}), cancelled: make(chan struct{})}
+	transport := newCoalescingTransport(inner)
+	url := "https://api.scryfall.com/cards/named?exact=Lightning+Bolt"
+
+	var wg sync.WaitGroup
+
+	bodies := make([]string, 5)
+
+	for i := range bodies {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+
+				return
+			}
+
+			body, _ := io.ReadAll(resp.Body)
+			bodies[i] = string(body)
+		}()
+	}
+
+	// One caller gives up, which must not affect the others.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelledReq, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	cancelledErr := make(chan error)
+
+	go func() {
+		_, err := transport.RoundTrip(cancelledReq)
+		cancelledErr <- err
+	}()
+
+	waitForWaiters(t, transport, url, 6)
+	cancel()
+
+	if err := <-cancelledErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	close(inner.release)
+	wg.Wait()
+
+	if inner.requests.Load() != 1 {
+		t.Errorf("expected 1 request, got %d", inner.requests.Load())
+	}
+
+	for i, body := range bodies {
+		if body != `{"name":"Lightning Bolt"}` {
+			t.Errorf("caller %d got unexpected body %q", i, body)
+		}
+	}
+}
+
+func Test_CoalescingTransport_AllCancelled(t *testing.T) {
+	t.Parallel()
+
+	inner := &blockingTransport{release: make(chan struct{}), cancelled: make(chan struct{})}
+	transport := newCoalescingTransport(inner)
+	url := "https://api.scryfall.com/cards/named?exact=Lightning+Bolt"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+	go func() {
+		waitForWaiters(t, transport, url, 1)
+		cancel()
+	}()
+
+	if _, err := transport.RoundTrip(req); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	select {
+	case <-inner.cancelled:
+	case <-time.After(time.Second):
+		t.Errorf("expected the shared request to be cancelled")
+	}
+}
+
+// lingerKey marks the context of the request lingeringTransport holds.
+type lingerKey struct{}
+
+// lingeringTransport holds the request marked with lingerKey until it is
+// cancelled and then finish is closed, and every other request until
+// release is closed.
+type lingeringTransport struct {
+	requests atomic.Int32
+	finish   chan struct{}
+	release  chan struct{}
+}
+
+func (l *lingeringTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	l.requests.Add(1)
+
+	if req.Context().Value(lingerKey{}) != nil {
+		<-req.Context().Done()
+		<-l.finish
+
+		return nil, req.Context().Err()
+	}
+
+	<-l.release
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(`{"name":"Lightning Bolt"}`)),
+	}, nil
+}
+
+func Test_CoalescingTransport_AfterCancel(t *testing.T) {
+	t.Parallel()
+
+	inner := &lingeringTransport{finish: make(chan struct{}), release: make(chan struct{})}
+	transport := newCoalescingTransport(inner)
+	url := "https://api.scryfall.com/cards/named?exact=Lightning+Bolt"
+
+	defer close(inner.release)
+	defer close(inner.finish)
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), lingerKey{}, true))
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+	cancelled := make(chan *coalescedCall, 1)
+
+	go func() {
+		waitForWaiters(t, transport, url, 1)
+
+		transport.lock.Lock()
+		cancelled <- transport.calls[url]
+		transport.lock.Unlock()
+
+		cancel()
+	}()
+
+	if _, err := transport.RoundTrip(req); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	// The cancelled request is still running, but a new caller must
+	// not join it.
+	result := make(chan error, 1)
+
+	go func() {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+
+		resp, err := transport.RoundTrip(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+
+		result <- err
+	}()
+
+	for i := 0; inner.requests.Load() != 2; i++ {
+		if i == 1000 {
+			t.Fatalf("expected a new request, got %d requests", inner.requests.Load())
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	// The cancelled request finishing must not forget the new one.
+	inner.finish <- struct{}{}
+	<-(<-cancelled).done
+
+	waitForWaiters(t, transport, url, 1)
+
+	inner.release <- struct{}{}
+
+	if err := <-result; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package gofall
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// collectorNumberPattern splits a collector number into the
+// non-numeric prefix, the first run of digits and everything after it.
+var collectorNumberPattern = regexp.MustCompile(`^(\D*)(\d+)(.*)$`)
+
+// CollectorNumber is a parsed collector number.  Most are plain numbers,
+// but some have a prefix or suffix, e.g. "123a", "S5", "A-123" or "12★".
+type CollectorNumber struct {
+	raw       string
+	prefix    string
+	number    int
+	suffix    string
+	hasNumber bool
+}
+
+// ParseCollectorNumber parses a collector number.  It never fails: a
+// collector number without any digits is kept as a prefix with no number.
+func ParseCollectorNumber(txt string) CollectorNumber {
+	match := collectorNumberPattern.FindStringSubmatch(txt)
+	if match == nil {
+		return CollectorNumber{raw: txt, prefix: txt}
+	}
+
+	number, err := strconv.Atoi(match[2])
+	if err != nil {
+		// Too many digits to fit in an int.
+		return CollectorNumber{raw: txt, prefix: txt}
+	}
+
+	return CollectorNumber{
+		raw:       txt,
+		prefix:    match[1],
+		number:    number,
+		suffix:    match[3],
+		hasNumber: true,
+	}
+}
+
+// ParsedCollectorNumber parses the card's CollectorNumber.
+func (c *Card) ParsedCollectorNumber() CollectorNumber {
+	return ParseCollectorNumber(c.CollectorNumber)
+}
+
+// Prefix returns the part before the number, e.g. "A-" for "A-123".
+func (c CollectorNumber) Prefix() string {
+	return c.prefix
+}
+
+// Number returns the numeric part, e.g. 123 for "123a".  It returns
+// false if the collector number has no digits.
+func (c CollectorNumber) Number() (int, bool) {
+	return c.number, c.hasNumber
+}
+
+// Suffix returns the part after the number, e.g. "a" for "123a".
+func (c CollectorNumber) Suffix() string {
+	return c.suffix
+}
+
+// String returns the collector number exactly as it was parsed.
+func (c CollectorNumber) String() string {
+	return c.raw
+}
+
+// Compare returns -1, 0 or 1 if c sorts before, equal to or after other.
+// Collector numbers sort naturally, the way OrderSet sorts within a set:
+// by number, then prefix, then suffix, so "2" < "10" < "10a" < "11".
+// Collector numbers without a number sort last.
+func (c CollectorNumber) Compare(other CollectorNumber) int {
+	switch {
+	case c.hasNumber != other.hasNumber:
+		if c.hasNumber {
+			return -1
+		}
+
+		return 1
+	case c.number != other.number:
+		if c.number < other.number {
+			return -1
+		}
+
+		return 1
+	case c.prefix != other.prefix:
+		return strings.Compare(c.prefix, other.prefix)
+	case c.suffix != other.suffix:
+		return strings.Compare(c.suffix, other.suffix)
+	default:
+		return strings.Compare(c.raw, other.raw)
+	}
+}
+
+// Less reports whether c sorts before other.  See Compare.
+func (c CollectorNumber) Less(other CollectorNumber) bool {
+	return c.Compare(other) < 0
+}
+
+// SortBySet sorts cards the same way as OrderSet: by set code,
+// then naturally by collector number.
+func SortBySet(cards []Card) {
+	sort.SliceStable(cards, func(i, j int) bool {
+		if cards[i].SetCode != cards[j].SetCode {
+			return cards[i].SetCode < cards[j].SetCode
+		}
+
+		return cards[i].ParsedCollectorNumber().Less(cards[j].ParsedCollectorNumber())
+	})
+}
+
+// SetChecklist returns the printings in cards that belong to the set,
+// in collector number order.  The set code is matched case-insensitively.
+// cards is not modified.
+func SetChecklist(cards []Card, setCode string) []Card {
+	var checklist []Card
+
+	for _, card := range cards {
+		if strings.EqualFold(card.SetCode, setCode) {
+			checklist = append(checklist, card)
+		}
+	}
+
+	SortBySet(checklist)
+
+	return checklist
+}
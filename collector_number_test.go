@@ -0,0 +1,73 @@
+package gofall_test
+
+import (
+	"testing"
+
+	"github.com/SethCurry/gofall"
+)
+
+func Test_ParseCollectorNumber(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		txt    string
+		prefix string
+		number int
+		suffix string
+	}{
+		{txt: "123", number: 123},
+		{txt: "123a", number: 123, suffix: "a"},
+		{txt: "S5", prefix: "S", number: 5},
+		{txt: "A-123", prefix: "A-", number: 123},
+		{txt: "★12", prefix: "★", number: 12},
+		{txt: "12★", number: 12, suffix: "★"},
+		{txt: "001", number: 1},
+	}
+
+	for _, v := range testCases {
+		parsed := gofall.ParseCollectorNumber(v.txt)
+		number, ok := parsed.Number()
+
+		if !ok || number != v.number || parsed.Prefix() != v.prefix || parsed.Suffix() != v.suffix {
+			t.Errorf("unexpected parse of %q: %q %d %q", v.txt, parsed.Prefix(), number, parsed.Suffix())
+		}
+
+		if parsed.String() != v.txt {
+			t.Errorf("expected %q to round trip, got %q", v.txt, parsed.String())
+		}
+	}
+
+	if _, ok := gofall.ParseCollectorNumber("★").Number(); ok {
+		t.Errorf("did not expect a number")
+	}
+}
+
+func Test_SetChecklist(t *testing.T) {
+	t.Parallel()
+
+	var cards []gofall.Card
+
+	for _, number := range []string{"10a", "★", "2", "A-10", "11", "10", "S1"} {
+		cards = append(cards, gofall.Card{SetCode: "tst", CollectorNumber: number})
+	}
+
+	cards = append(cards, gofall.Card{SetCode: "oth", CollectorNumber: "1"})
+
+	checklist := gofall.SetChecklist(cards, "TST")
+
+	expected := []string{"S1", "2", "10", "10a", "A-10", "11", "★"}
+
+	if len(checklist) != len(expected) {
+		t.Fatalf("expected %d cards, got %d", len(expected), len(checklist))
+	}
+
+	for i, number := range expected {
+		if checklist[i].CollectorNumber != number {
+			t.Errorf("expected %q at position %d, got %q", number, i, checklist[i].CollectorNumber)
+		}
+	}
+
+	if cards[0].CollectorNumber != "10a" {
+		t.Errorf("SetChecklist modified its input")
+	}
+}
@@ -0,0 +1,116 @@
+// Package commander implements rules specific to the Commander format and
+// its variants that cannot be read directly from a gofall.Card, such as
+// which cards can be a commander and which commanders can be paired.
+package commander
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/SethCurry/gofall"
+)
+
+// frontFace returns the part of a field that belongs to the front face
+// of a multi-faced card.
+func frontFace(field string) string {
+	front, _, _ := strings.Cut(field, " // ")
+
+	return front
+}
+
+// frontTypes returns the parsed type line of the card's front face.
+func frontTypes(card *gofall.Card) gofall.TypeLineFace {
+	return card.ParsedTypeLine().Front()
+}
+
+// hasKeyword reports whether the card has the keyword, ignoring case.
+func hasKeyword(card *gofall.Card, keyword string) bool {
+	for _, k := range card.Keywords {
+		if strings.EqualFold(k, keyword) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// canBeCommanderPattern matches the Oracle text of cards like
+// Teferi, Temporal Archmage that are not creatures but can lead a deck.
+var canBeCommanderPattern = regexp.MustCompile(`(?i)\bcan be your commander\b`)
+
+// CanBeCommander reports whether the card can be a commander on its own or
+// as one of a pair: either a legendary creature, or a card whose text says
+// it can be your commander.  Backgrounds, which can only be a second
+// commander, are also allowed; see IsBackground.
+func CanBeCommander(card *gofall.Card) bool {
+	types := frontTypes(card)
+
+	if types.IsLegendary() && types.IsCreature() {
+		return true
+	}
+
+	return canBeCommanderPattern.MatchString(card.FrontOracleText()) || IsBackground(card)
+}
+
+// IsBackground reports whether the card is a Background enchantment,
+// which can be a second commander alongside a creature that can choose one.
+func IsBackground(card *gofall.Card) bool {
+	types := frontTypes(card)
+
+	return types.IsLegendary() && types.HasType("Enchantment") && types.HasSubtype("Background")
+}
+
+// IsDoctor reports whether the card is a Time Lord Doctor, which can be
+// paired with a card with Doctor's companion.
+func IsDoctor(card *gofall.Card) bool {
+	types := frontTypes(card)
+
+	return types.IsCreature() && types.HasSubtype("Time Lord") && types.HasSubtype("Doctor")
+}
+
+// colorOrder is the conventional WUBRG ordering of colors.
+var colorOrder = []string{"W", "U", "B", "R", "G"}
+
+// ColorIdentity returns the combined color identity of one or more
+// commanders, in WUBRG order.
+func ColorIdentity(commanders ...*gofall.Card) []string {
+	colors := map[string]bool{}
+
+	for _, card := range commanders {
+		for _, color := range card.ColorIdentity {
+			colors[color] = true
+		}
+	}
+
+	identity := []string{}
+
+	for _, color := range colorOrder {
+		if colors[color] {
+			identity = append(identity, color)
+		}
+	}
+
+	return identity
+}
+
+// WithinIdentity reports whether the card's color identity is
+// a subset of identity, so it can be played in the deck.
+func WithinIdentity(card *gofall.Card, identity []string) bool {
+	for _, color := range card.ColorIdentity {
+		found := false
+
+		for _, allowed := range identity {
+			if color == allowed {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,234 @@
+package commander_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/SethCurry/gofall"
+	"github.com/SethCurry/gofall/commander"
+)
+
+func loadCards(t *testing.T) map[string]*gofall.Card {
+	t.Helper()
+
+	contents, err := os.ReadFile("../test/commander_cards.json")
+	if err != nil {
+		t.Fatalf("failed to read test cards file: %v", err)
+	}
+
+	var cards []gofall.Card
+
+	if err := json.Unmarshal(contents, &cards); err != nil {
+		t.Fatalf("failed to unmarshal test cards: %v", err)
+	}
+
+	byName := map[string]*gofall.Card{}
+	for i := range cards {
+		byName[cards[i].Name] = &cards[i]
+	}
+
+	return byName
+}
+
+func TestCanBeCommander(t *testing.T) {
+	t.Parallel()
+
+	cards := loadCards(t)
+
+	testCases := map[string]bool{
+		"Krenko, Mob Boss":          true,
+		"Teferi, Temporal Archmage": true,
+		"Raised by Giants":          true,
+		"Lightning Bolt":            false,
+		"Sol Ring":                  false,
+	}
+
+	for name, want := range testCases {
+		if got := commander.CanBeCommander(cards[name]); got != want {
+			t.Errorf("CanBeCommander(%q) = %v, want %v", name, got, want)
+		}
+	}
+
+	if commander.ValidCommanders(cards["Raised by Giants"]) {
+		t.Errorf("a Background should not be a valid commander on its own")
+	}
+
+	if !commander.ValidCommanders(cards["Krenko, Mob Boss"]) {
+		t.Errorf("Krenko should be a valid commander on its own")
+	}
+}
+
+func TestCanPair(t *testing.T) {
+	t.Parallel()
+
+	cards := loadCards(t)
+
+	testCases := []struct {
+		first, second string
+		want          commander.Pairing
+		ok            bool
+	}{
+		{"Thrasios, Triton Hero", "Tymna the Weaver", commander.PairingPartner, true},
+		{"Pir, Imaginative Rascal", "Toothy, Imaginary Friend", commander.PairingPartnerWith, true},
+		{"Pir, Imaginative Rascal", "Thrasios, Triton Hero", "", false},
+		{"Will the Wise", "Mike, the Dungeon Master", commander.PairingFriendsForever, true},
+		{"Will the Wise", "Thrasios, Triton Hero", "", false},
+		{"Raised by Giants", "Wilson, Refined Grizzly", commander.PairingBackground, true},
+		{"Krenko, Mob Boss", "Raised by Giants", "", false},
+		{"Clara Oswald", "The Fourteenth Doctor", commander.PairingDoctorsCompanion, true},
+		{"Clara Oswald", "Krenko, Mob Boss", "", false},
+		{"Thrasios, Triton Hero", "Thrasios, Triton Hero", "", false},
+	}
+
+	for _, v := range testCases {
+		got, ok := commander.CanPair(cards[v.first], cards[v.second])
+		if got != v.want || ok != v.ok {
+			t.Errorf("CanPair(%q, %q) = %q, %v, want %q, %v", v.first, v.second, got, ok, v.want, v.ok)
+		}
+	}
+
+	if !commander.ValidCommanders(cards["Wilson, Refined Grizzly"], cards["Raised by Giants"]) {
+		t.Errorf("Wilson and a Background should be valid commanders")
+	}
+}
+
+func TestColorIdentity(t *testing.T) {
+	t.Parallel()
+
+	cards := loadCards(t)
+
+	identity := commander.ColorIdentity(cards["Thrasios, Triton Hero"], cards["Tymna the Weaver"])
+
+	expected := []string{"W", "U", "B", "G"}
+	if len(identity) != len(expected) {
+		t.Fatalf("unexpected identity %v", identity)
+	}
+
+	for i, color := range expected {
+		if identity[i] != color {
+			t.Errorf("unexpected identity %v, want %v", identity, expected)
+
+			break
+		}
+	}
+
+	if commander.WithinIdentity(cards["Lightning Bolt"], identity) {
+		t.Errorf("Lightning Bolt should be outside of the identity")
+	}
+
+	if !commander.WithinIdentity(cards["Sol Ring"], identity) {
+		t.Errorf("Sol Ring should be within every identity")
+	}
+}
+
+func TestCheckCompanion(t *testing.T) {
+	t.Parallel()
+
+	cards := loadCards(t)
+
+	deck := []*gofall.Card{cards["Sol Ring"], cards["Lightning Bolt"], cards["Krenko, Mob Boss"], cards["Mountain"]}
+
+	result, err := commander.CheckCompanion(cards["Lurrus of the Dream-Den"], deck, 60)
+	if err != nil {
+		t.Fatalf("failed to check companion: %v", err)
+	}
+
+	if result.Satisfied || len(result.Offending) != 1 || result.Offending[0].Name != "Krenko, Mob Boss" {
+		t.Errorf("expected Krenko to break Lurrus' restriction, got %+v", result)
+	}
+
+	result, err = commander.CheckCompanion(cards["Jegantha, the Wellspring"], deck, 60)
+	if err != nil {
+		t.Fatalf("failed to check companion: %v", err)
+	}
+
+	if result.Satisfied || len(result.Offending) != 1 || result.Offending[0].Name != "Krenko, Mob Boss" {
+		t.Errorf("expected Krenko to break Jegantha's restriction, got %+v", result)
+	}
+
+	if !commander.IsCompanion(cards["Lurrus of the Dream-Den"]) || commander.IsCompanion(cards["Krenko, Mob Boss"]) {
+		t.Errorf("unexpected result from IsCompanion")
+	}
+
+	if _, err := commander.CheckCompanion(cards["Krenko, Mob Boss"], deck, 60); !errors.Is(err, commander.ErrUnknownCompanion) {
+		t.Errorf("expected ErrUnknownCompanion, got %v", err)
+	}
+}
+
+func TestCheckCompanion_Zirda(t *testing.T) {
+	t.Parallel()
+
+	deck := []*gofall.Card{
+		{Name: "Forest", TypeLine: "Basic Land — Forest", OracleText: "({T}: Add {G}.)"},
+		{Name: "Bayou", TypeLine: "Land — Swamp Forest"},
+		{Name: "Bonesplitter", TypeLine: "Artifact — Equipment", OracleText: "Equipped creature gets +2/+0.\nEquip {1}"},
+		{Name: "Ornithopter", TypeLine: "Artifact Creature — Thopter", OracleText: "Flying"},
+		{Name: "Lightning Bolt", TypeLine: "Instant", OracleText: "Lightning Bolt deals 3 damage to any target."},
+	}
+
+	result, err := commander.CheckCompanion(&gofall.Card{Name: "Zirda, the Dawnwaker"}, deck, 60)
+	if err != nil {
+		t.Fatalf("failed to check companion: %v", err)
+	}
+
+	if result.Satisfied || len(result.Offending) != 1 || result.Offending[0].Name != "Ornithopter" {
+		t.Errorf("expected only Ornithopter to break Zirda's restriction, got %+v", result)
+	}
+}
+
+func TestDoubleFacedCards(t *testing.T) {
+	t.Parallel()
+
+	cards := loadCards(t)
+
+	// Scryfall only puts the Oracle text and mana cost of double-faced
+	// cards on their faces.
+	partner := &gofall.Card{
+		Name:     "Test Partner // Test Partner Transformed",
+		TypeLine: "Legendary Creature — Human // Legendary Creature — Horror",
+		Keywords: []string{"Partner", "Transform"},
+		CardFaces: []gofall.CardFace{
+			{Name: "Test Partner", OracleText: "Partner (You can have two commanders if both have partner.)"},
+			{Name: "Test Partner Transformed", OracleText: "Flying"},
+		},
+	}
+
+	if pairing, ok := commander.CanPair(partner, cards["Thrasios, Triton Hero"]); !ok || pairing != commander.PairingPartner {
+		t.Errorf("expected a double-faced card with partner to pair, got %q, %v", pairing, ok)
+	}
+
+	planeswalker := &gofall.Card{
+		Name:     "Test Walker // Test Walker Flipped",
+		TypeLine: "Legendary Planeswalker — Test // Legendary Planeswalker — Test",
+		CardFaces: []gofall.CardFace{
+			{Name: "Test Walker", OracleText: "+1: Draw a card.\nTest Walker can be your commander."},
+			{Name: "Test Walker Flipped", OracleText: "-1: Discard a card."},
+		},
+	}
+
+	if !commander.CanBeCommander(planeswalker) {
+		t.Errorf("expected a double-faced card whose text says so to be a commander")
+	}
+
+	deck := []*gofall.Card{cards["Emeria's Call // Emeria, Shattered Skyclave"], cards["Westvale Abbey // Ormendahl, Profane Prince"]}
+
+	result, err := commander.CheckCompanion(cards["Jegantha, the Wellspring"], deck, 60)
+	if err != nil {
+		t.Fatalf("failed to check companion: %v", err)
+	}
+
+	if result.Satisfied || len(result.Offending) != 1 || result.Offending[0] != deck[0] {
+		t.Errorf("expected Emeria's Call to break Jegantha's restriction, got %+v", result)
+	}
+
+	result, err = commander.CheckCompanion(&gofall.Card{Name: "Zirda, the Dawnwaker"}, deck[1:], 60)
+	if err != nil {
+		t.Fatalf("failed to check companion: %v", err)
+	}
+
+	if !result.Satisfied {
+		t.Errorf("expected Westvale Abbey's activated abilities to satisfy Zirda, got %+v", result)
+	}
+}
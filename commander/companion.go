@@ -0,0 +1,286 @@
+package commander
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/SethCurry/gofall"
+)
+
+// ErrUnknownCompanion is returned when checking a companion restriction
+// for a card that is not one of the known companions.
+var ErrUnknownCompanion = errors.New("unknown companion")
+
+// CompanionResult is the outcome of checking a deck against a companion's
+// deck building restriction.
+type CompanionResult struct {
+	// Satisfied is true if the deck meets the restriction.
+	Satisfied bool
+
+	// Offending are the cards that break the restriction.  It may be empty
+	// even when the restriction is not satisfied, if the restriction is about
+	// the deck as a whole, e.g. Yorion's deck size.
+	Offending []*gofall.Card
+
+	// Reason describes why the restriction is not satisfied.
+	Reason string
+}
+
+// companionCheck checks a starting deck against a single companion's restriction.
+type companionCheck func(deck []*gofall.Card, minDeckSize int) CompanionResult
+
+var companionChecks = map[string]companionCheck{
+	"Gyruda, Doom of Depths": eachCard(
+		func(card *gofall.Card) bool { return true },
+		func(card *gofall.Card) bool { return int(card.CMC)%2 == 0 },
+		"has an odd mana value",
+	),
+	"Jegantha, the Wellspring": eachCard(
+		func(card *gofall.Card) bool { return true },
+		func(card *gofall.Card) bool { return !hasRepeatedSymbol(card.FrontManaCost()) },
+		"has more than one of the same mana symbol in its mana cost",
+	),
+	"Kaheera, the Orphanguard": eachCard(
+		isCreature,
+		func(card *gofall.Card) bool {
+			types := frontTypes(card)
+			for _, subtype := range []string{"Cat", "Elemental", "Nightmare", "Dinosaur", "Beast"} {
+				if types.HasSubtype(subtype) {
+					return true
+				}
+			}
+
+			return false
+		},
+		"is a creature that is not a Cat, Elemental, Nightmare, Dinosaur, or Beast",
+	),
+	"Keruga, the Macrosage": eachCard(
+		isNonland,
+		func(card *gofall.Card) bool { return card.CMC >= 3 },
+		"is a nonland card with mana value less than 3",
+	),
+	"Lurrus of the Dream-Den": eachCard(
+		isPermanent,
+		func(card *gofall.Card) bool { return card.CMC <= 2 },
+		"is a permanent card with mana value greater than 2",
+	),
+	"Obosh, the Preypiercer": eachCard(
+		isNonland,
+		func(card *gofall.Card) bool { return int(card.CMC)%2 == 1 },
+		"is a nonland card with an even mana value",
+	),
+	"Zirda, the Dawnwaker": eachCard(
+		isPermanent,
+		hasActivatedAbility,
+		"is a permanent card without an activated ability",
+	),
+	"Lutri, the Spellchaser": checkLutri,
+	"Umori, the Collector":   checkUmori,
+	"Yorion, Sky Nomad":      checkYorion,
+}
+
+// IsCompanion reports whether the card is one of the companions whose
+// restriction can be checked by CheckCompanion.
+func IsCompanion(card *gofall.Card) bool {
+	_, ok := companionChecks[frontFace(card.Name)]
+
+	return ok
+}
+
+// CheckCompanion checks whether a starting deck meets the companion's deck
+// building restriction.  deck should contain one element per copy of each
+// card, and not include the companion itself.  minDeckSize is the format's
+// minimum deck size, which Yorion's restriction depends on.
+//
+// It returns ErrUnknownCompanion if the card is not a known companion.
+func CheckCompanion(companion *gofall.Card, deck []*gofall.Card, minDeckSize int) (CompanionResult, error) {
+	check, ok := companionChecks[frontFace(companion.Name)]
+	if !ok {
+		return CompanionResult{}, fmt.Errorf("%w: %s", ErrUnknownCompanion, companion.Name)
+	}
+
+	return check(deck, minDeckSize), nil
+}
+
+// eachCard builds a check requiring every card matching applies to pass.
+func eachCard(applies, passes func(*gofall.Card) bool, reason string) companionCheck {
+	return func(deck []*gofall.Card, _ int) CompanionResult {
+		result := CompanionResult{Satisfied: true}
+
+		for _, card := range deck {
+			if applies(card) && !passes(card) {
+				result.Satisfied = false
+				result.Offending = append(result.Offending, card)
+			}
+		}
+
+		if !result.Satisfied {
+			result.Reason = fmt.Sprintf("%d cards in the deck: each %s", len(result.Offending), reason)
+		}
+
+		return result
+	}
+}
+
+func isCreature(card *gofall.Card) bool {
+	return frontTypes(card).IsCreature()
+}
+
+func isNonland(card *gofall.Card) bool {
+	return !frontTypes(card).IsLand()
+}
+
+// permanentTypes are the card types of permanents.
+var permanentTypes = []string{"Artifact", "Battle", "Creature", "Enchantment", "Land", "Planeswalker"}
+
+func isPermanent(card *gofall.Card) bool {
+	types := frontTypes(card)
+
+	for _, permanentType := range permanentTypes {
+		if types.HasType(permanentType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// activatedAbilityPattern loosely matches an activated ability: a cost
+// followed by a colon at the start of a line of Oracle text.  The line may
+// be reminder text, as with the mana abilities of basic lands.
+var activatedAbilityPattern = regexp.MustCompile(`(?m)^\(?[^"(\n]*:`)
+
+// activatedKeywords are keyword abilities that are activated abilities,
+// which are written without a colon, e.g. "Equip {1}".
+var activatedKeywords = []string{
+	"Crew", "Cycling", "Embalm", "Equip", "Eternalize", "Fortify", "Level up",
+	"Ninjutsu", "Outlast", "Reconfigure", "Scavenge", "Transmute", "Unearth",
+}
+
+// activatedKeywordPattern matches a line of Oracle text starting with
+// one of activatedKeywords, or a kind of landcycling.
+var activatedKeywordPattern = regexp.MustCompile(
+	`(?mi)^(?:` + strings.Join(activatedKeywords, "|") + `|\w+cycling)\b`,
+)
+
+// basicLandTypes give lands an intrinsic mana ability, even if their
+// Oracle text does not say so.
+var basicLandTypes = []string{"Plains", "Island", "Swamp", "Mountain", "Forest"}
+
+// hasActivatedAbility reports whether the card has an activated ability,
+// for Zirda's restriction.
+func hasActivatedAbility(card *gofall.Card) bool {
+	text := card.FrontOracleText()
+
+	if activatedAbilityPattern.MatchString(text) || activatedKeywordPattern.MatchString(text) {
+		return true
+	}
+
+	for _, keyword := range card.Keywords {
+		if strings.HasSuffix(strings.ToLower(keyword), "cycling") {
+			return true
+		}
+
+		for _, activated := range activatedKeywords {
+			if strings.EqualFold(keyword, activated) {
+				return true
+			}
+		}
+	}
+
+	types := frontTypes(card)
+
+	if types.IsLand() {
+		for _, landType := range basicLandTypes {
+			if types.HasSubtype(landType) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func hasRepeatedSymbol(cost string) bool {
+	seen := map[string]bool{}
+
+	for _, symbol := range gofall.ManaSymbols(cost) {
+		if seen[symbol] {
+			return true
+		}
+
+		seen[symbol] = true
+	}
+
+	return false
+}
+
+func checkLutri(deck []*gofall.Card, _ int) CompanionResult {
+	result := CompanionResult{Satisfied: true}
+	seen := map[string]bool{}
+
+	for _, card := range deck {
+		if !isNonland(card) {
+			continue
+		}
+
+		if seen[card.Name] {
+			result.Satisfied = false
+			result.Offending = append(result.Offending, card)
+		}
+
+		seen[card.Name] = true
+	}
+
+	if !result.Satisfied {
+		result.Reason = "each nonland card in the deck must have a different name"
+	}
+
+	return result
+}
+
+func checkUmori(deck []*gofall.Card, _ int) CompanionResult {
+	var nonland []*gofall.Card
+
+	for _, card := range deck {
+		if isNonland(card) {
+			nonland = append(nonland, card)
+		}
+	}
+
+	for _, cardType := range gofall.AllCardTypes() {
+		shared := true
+
+		for _, card := range nonland {
+			if !frontTypes(card).HasType(cardType) {
+				shared = false
+
+				break
+			}
+		}
+
+		if shared {
+			return CompanionResult{Satisfied: true}
+		}
+	}
+
+	return CompanionResult{
+		Satisfied: false,
+		Reason:    "the nonland cards in the deck do not all share a card type",
+	}
+}
+
+func checkYorion(deck []*gofall.Card, minDeckSize int) CompanionResult {
+	if len(deck) >= minDeckSize+20 {
+		return CompanionResult{Satisfied: true}
+	}
+
+	return CompanionResult{
+		Satisfied: false,
+		Reason: fmt.Sprintf(
+			"the deck has %d cards, but must have at least %d", len(deck), minDeckSize+20,
+		),
+	}
+}
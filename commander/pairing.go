@@ -0,0 +1,124 @@
+package commander
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/SethCurry/gofall"
+)
+
+// Pairing is a rule that allows a deck to have two commanders.
+type Pairing string
+
+const (
+	// PairingPartner pairs two commanders that both have partner.
+	PairingPartner Pairing = "partner"
+
+	// PairingPartnerWith pairs two commanders that name each other
+	// with "Partner with".
+	PairingPartnerWith Pairing = "partner_with"
+
+	// PairingFriendsForever pairs two commanders that both have friends forever.
+	PairingFriendsForever Pairing = "friends_forever"
+
+	// PairingBackground pairs a commander that can choose a Background
+	// with a Background.
+	PairingBackground Pairing = "background"
+
+	// PairingDoctorsCompanion pairs a commander with Doctor's companion
+	// with a Time Lord Doctor.
+	PairingDoctorsCompanion Pairing = "doctors_companion"
+)
+
+// partnerWithPattern matches "Partner with" and the name of the partner,
+// stopping at the reminder text.
+var partnerWithPattern = regexp.MustCompile(`(?m)^Partner with ([^(\n]+?)\s*(?:\(|$)`)
+
+// plainPartnerPattern matches the plain partner ability, without "with".
+var plainPartnerPattern = regexp.MustCompile(`(?m)^Partner(?:\s*\(|$)`)
+
+// PartnerWith returns the name of the card named by the card's
+// "Partner with" ability, or an empty string if it does not have one.
+func PartnerWith(card *gofall.Card) string {
+	match := partnerWithPattern.FindStringSubmatch(card.FrontOracleText())
+	if match == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(match[1])
+}
+
+// hasPlainPartner reports whether the card has partner without a named
+// partner.  Scryfall lists both kinds under the "Partner" keyword, so the
+// Oracle text is checked as well.
+func hasPlainPartner(card *gofall.Card) bool {
+	text := card.FrontOracleText()
+
+	if plainPartnerPattern.MatchString(text) {
+		return true
+	}
+
+	return text == "" && hasKeyword(card, "Partner") && !hasKeyword(card, "Partner with")
+}
+
+func choosesBackground(card *gofall.Card) bool {
+	return hasKeyword(card, "Choose a background") ||
+		strings.Contains(strings.ToLower(card.FrontOracleText()), "choose a background")
+}
+
+func isDoctorsCompanion(card *gofall.Card) bool {
+	return hasKeyword(card, "Doctor's companion") ||
+		strings.Contains(strings.ToLower(card.FrontOracleText()), "doctor's companion")
+}
+
+func hasFriendsForever(card *gofall.Card) bool {
+	return hasKeyword(card, "Friends forever") ||
+		strings.Contains(strings.ToLower(card.FrontOracleText()), "friends forever")
+}
+
+// CanPair reports whether two cards can be commanders of the same deck,
+// and which rule allows it.  It does not check that each card can be a
+// commander; see CanBeCommander.
+func CanPair(first, second *gofall.Card) (Pairing, bool) {
+	if first.Name == second.Name {
+		return "", false
+	}
+
+	switch {
+	case strings.EqualFold(PartnerWith(first), frontFace(second.Name)) &&
+		strings.EqualFold(PartnerWith(second), frontFace(first.Name)):
+		return PairingPartnerWith, true
+	case hasPlainPartner(first) && hasPlainPartner(second):
+		return PairingPartner, true
+	case hasFriendsForever(first) && hasFriendsForever(second):
+		return PairingFriendsForever, true
+	case choosesBackground(first) && IsBackground(second),
+		choosesBackground(second) && IsBackground(first):
+		return PairingBackground, true
+	case isDoctorsCompanion(first) && IsDoctor(second),
+		isDoctorsCompanion(second) && IsDoctor(first):
+		return PairingDoctorsCompanion, true
+	default:
+		return "", false
+	}
+}
+
+// ValidCommanders reports whether the cards are a valid set of commanders
+// for a deck: a single card that can be a commander and is not a
+// Background, or two cards that can be paired.
+func ValidCommanders(commanders ...*gofall.Card) bool {
+	switch len(commanders) {
+	case 1:
+		return CanBeCommander(commanders[0]) && !IsBackground(commanders[0])
+	case 2:
+		if !CanBeCommander(commanders[0]) || !CanBeCommander(commanders[1]) {
+			return false
+		}
+
+		_, ok := CanPair(commanders[0], commanders[1])
+
+		return ok
+	default:
+		return false
+	}
+}
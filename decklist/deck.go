@@ -0,0 +1,145 @@
+// Package decklist parses and writes Magic: The Gathering deck lists in the
+// formats used by MTG Arena, MTG Online, Moxfield and plain text, and
+// resolves their entries to gofall.Card values.
+package decklist
+
+import (
+	"strings"
+
+	"github.com/SethCurry/gofall"
+)
+
+// Section is a part of a deck, such as the main deck or the sideboard.
+type Section string
+
+const (
+	// SectionMain is the main deck.
+	SectionMain Section = "main"
+
+	// SectionSideboard is the sideboard.
+	SectionSideboard Section = "sideboard"
+
+	// SectionCommander holds the commander(s) of a Commander-style deck.
+	SectionCommander Section = "commander"
+
+	// SectionCompanion holds the deck's companion.
+	SectionCompanion Section = "companion"
+)
+
+// AllSections returns all possible values of Section, in the order
+// they are usually listed.
+func AllSections() []Section {
+	return []Section{SectionCommander, SectionCompanion, SectionMain, SectionSideboard}
+}
+
+// Entry is a single line of a deck list: a number of copies of a card.
+type Entry struct {
+	// Quantity is the number of copies of the card.
+	Quantity int
+
+	// Name is the name of the card as written in the list.  For double-faced
+	// cards this may be just the front face name.
+	Name string
+
+	// SetCode is the code of the set of the printing.  Optional.
+	SetCode string
+
+	// CollectorNumber is the collector number of the printing.  Optional,
+	// and only meaningful together with SetCode.
+	CollectorNumber string
+
+	// Foil is set if the list marks the entry as foil or etched.
+	Foil bool
+
+	// MTGOID is the MTGO catalog ID of the card, as used in .dek files.
+	// Optional.
+	MTGOID int
+
+	// Card is the card the entry refers to.  It is nil until the
+	// deck is resolved with Resolve.
+	Card *gofall.Card
+}
+
+// Deck is a parsed deck list.
+type Deck struct {
+	// Name is the name of the deck, if the list included one.
+	Name string
+
+	Main      []Entry
+	Sideboard []Entry
+	Commander []Entry
+	Companion []Entry
+}
+
+// Section returns a pointer to the entries of the given section,
+// or nil if the section is not known.
+func (d *Deck) Section(section Section) *[]Entry {
+	switch section {
+	case SectionMain:
+		return &d.Main
+	case SectionSideboard:
+		return &d.Sideboard
+	case SectionCommander:
+		return &d.Commander
+	case SectionCompanion:
+		return &d.Companion
+	default:
+		return nil
+	}
+}
+
+// Add appends an entry to the given section.  If the section already has an
+// entry for the same printing, its quantity is increased instead.
+func (d *Deck) Add(section Section, entry Entry) {
+	entries := d.Section(section)
+	if entries == nil {
+		return
+	}
+
+	for i, existing := range *entries {
+		if strings.EqualFold(existing.Name, entry.Name) &&
+			strings.EqualFold(existing.SetCode, entry.SetCode) &&
+			existing.CollectorNumber == entry.CollectorNumber &&
+			existing.Foil == entry.Foil {
+			(*entries)[i].Quantity += entry.Quantity
+
+			return
+		}
+	}
+
+	*entries = append(*entries, entry)
+}
+
+// Count returns the total number of cards in the given section.
+func (d *Deck) Count(section Section) int {
+	entries := d.Section(section)
+	if entries == nil {
+		return 0
+	}
+
+	total := 0
+	for _, entry := range *entries {
+		total += entry.Quantity
+	}
+
+	return total
+}
+
+// Quantities returns the total number of copies of each card name across
+// all sections.  It can be passed to gofall.TotalCheapestPrinting.
+func (d *Deck) Quantities() map[string]int {
+	quantities := map[string]int{}
+
+	for _, section := range AllSections() {
+		for _, entry := range *d.Section(section) {
+			name := entry.Name
+			if entry.Card != nil {
+				name = entry.Card.Name
+			}
+
+			quantities[name] += entry.Quantity
+		}
+	}
+
+	return quantities
+}
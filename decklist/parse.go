@@ -0,0 +1,288 @@
+package decklist
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrUnknownFormat is returned when parsing or writing a deck list
+// in a Format that is not known.
+var ErrUnknownFormat = errors.New("unknown deck list format")
+
+// Format is a deck list file format.
+type Format string
+
+const (
+	// FormatAuto detects the format when parsing.  It cannot be used for writing.
+	FormatAuto Format = ""
+
+	// FormatArena is MTG Arena's import/export format, with "Commander",
+	// "Companion", "Deck" and "Sideboard" section headers and
+	// "4 Lightning Bolt (M10) 146" entries.
+	FormatArena Format = "arena"
+
+	// FormatMTGO is MTG Online's .txt format: "4 Lightning Bolt" entries,
+	// with the sideboard after a blank line.  MTGO keeps commanders and
+	// companions in the sideboard.
+	FormatMTGO Format = "mtgo"
+
+	// FormatMTGODek is MTG Online's .dek XML format.
+	FormatMTGODek Format = "mtgo_dek"
+
+	// FormatMoxfield is Moxfield's text export: "1 Sol Ring (C21) 263 *F*"
+	// entries, with "SIDEBOARD:", "COMMANDER:" and "COMPANION:" headers
+	// after the main deck.
+	FormatMoxfield Format = "moxfield"
+
+	// FormatPlain is plain text: "4 Lightning Bolt (M10) 146" entries with
+	// optional set and collector number, and the sideboard after a blank line.
+	FormatPlain Format = "plain"
+)
+
+// ParseError is returned when a line of a text deck list cannot be parsed.
+type ParseError struct {
+	// Line is the one-based line number.
+	Line int
+
+	// Text is the contents of the line.
+	Text string
+}
+
+func (p *ParseError) Error() string {
+	return fmt.Sprintf("failed to parse deck list line %d: %q", p.Line, p.Text)
+}
+
+// ParseString parses a deck list from a string.  See Parse.
+func ParseString(list string, format Format) (*Deck, error) {
+	return Parse(strings.NewReader(list), format)
+}
+
+// Parse parses a deck list in the given format.  With FormatAuto, MTGO .dek
+// files are detected by their XML, and everything else is parsed as text.
+//
+// All of the text formats are parsed the same way, so a list in any of them
+// can be parsed with any text Format.  Sections are started by headers such
+// as "Sideboard" or "SIDEBOARD:".  In lists without any headers, a blank line
+// after the main deck starts the sideboard.  Sections that do not map to a
+// Section, such as "Maybeboard", are skipped.
+func Parse(src io.Reader, format Format) (*Deck, error) {
+	contents, err := io.ReadAll(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deck list: %w", err)
+	}
+
+	switch format {
+	case FormatAuto:
+		if bytes.HasPrefix(bytes.TrimSpace(contents), []byte("<")) {
+			return parseDek(contents)
+		}
+
+		return parseText(contents)
+	case FormatMTGODek:
+		return parseDek(contents)
+	case FormatArena, FormatMTGO, FormatMoxfield, FormatPlain:
+		return parseText(contents)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+// sectionAbout is Arena's deck metadata section.  It is not a real Section.
+const sectionAbout Section = "about"
+
+// sectionSkipped is a section whose entries are ignored, such as a maybeboard.
+const sectionSkipped Section = "skipped"
+
+// headers maps lowercased section headers to the section they start.
+var headers = map[string]Section{
+	"about":       sectionAbout,
+	"deck":        SectionMain,
+	"main":        SectionMain,
+	"maindeck":    SectionMain,
+	"mainboard":   SectionMain,
+	"sideboard":   SectionSideboard,
+	"side":        SectionSideboard,
+	"commander":   SectionCommander,
+	"commanders":  SectionCommander,
+	"companion":   SectionCompanion,
+	"maybeboard":  sectionSkipped,
+	"maybe":       sectionSkipped,
+	"considering": sectionSkipped,
+	"tokens":      sectionSkipped,
+}
+
+// entryPattern matches the quantity and the rest of an entry line,
+// e.g. "4 Lightning Bolt (M10) 146" or "4x Lightning Bolt".
+var entryPattern = regexp.MustCompile(`^(\d+)[xX]?\s+(.+)$`)
+
+// printingPattern matches the set and collector number at the end of an entry.
+var printingPattern = regexp.MustCompile(`^(.+?)\s+\(([A-Za-z0-9]+)\)(?:\s+(\S+))?$`)
+
+// foilPattern matches Moxfield's foil and etched markers.
+var foilPattern = regexp.MustCompile(`\s+\*[FE]\*$`)
+
+func parseText(contents []byte) (*Deck, error) {
+	deck := &Deck{}
+	section := SectionMain
+	sawHeader := false
+	sawEntry := false
+	sawBlank := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" {
+			sawBlank = sawEntry
+
+			continue
+		}
+
+		if header, ok := parseHeader(line); ok {
+			section = header
+			sawHeader = true
+			sawBlank = false
+
+			continue
+		}
+
+		if strings.HasPrefix(line, "//") || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if section == sectionAbout {
+			if name, ok := strings.CutPrefix(line, "Name "); ok {
+				deck.Name = strings.TrimSpace(name)
+			}
+
+			continue
+		}
+
+		// Lists without headers use a blank line before the sideboard.
+		if sawBlank && !sawHeader && section == SectionMain {
+			section = SectionSideboard
+		}
+
+		sawBlank = false
+
+		entrySection := section
+
+		if rest, ok := strings.CutPrefix(line, "SB:"); ok {
+			line = strings.TrimSpace(rest)
+			entrySection = SectionSideboard
+		}
+
+		entry, err := parseEntry(line)
+		if err != nil {
+			return nil, &ParseError{Line: lineNumber, Text: scanner.Text()}
+		}
+
+		sawEntry = true
+
+		if entrySection != sectionSkipped {
+			deck.Add(entrySection, entry)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read deck list: %w", err)
+	}
+
+	return deck, nil
+}
+
+// parseHeader returns the section started by line, if it is a header.
+func parseHeader(line string) (Section, bool) {
+	header := strings.TrimSpace(strings.TrimPrefix(line, "//"))
+	header = strings.TrimSuffix(header, ":")
+
+	section, ok := headers[strings.ToLower(strings.TrimSpace(header))]
+
+	return section, ok
+}
+
+// parseEntry parses a single entry such as "4 Lightning Bolt (M10) 146".
+// Lines without a quantity, such as "Lightning Bolt", are a single copy.
+func parseEntry(line string) (Entry, error) {
+	entry := Entry{Quantity: 1}
+
+	if match := entryPattern.FindStringSubmatch(line); match != nil {
+		quantity, err := strconv.Atoi(match[1])
+		if err != nil {
+			return Entry{}, fmt.Errorf("invalid quantity: %w", err)
+		}
+
+		entry.Quantity = quantity
+		line = match[2]
+	}
+
+	if foilPattern.MatchString(line) {
+		entry.Foil = true
+		line = foilPattern.ReplaceAllString(line, "")
+	}
+
+	if match := printingPattern.FindStringSubmatch(line); match != nil {
+		line = match[1]
+		entry.SetCode = strings.ToLower(match[2])
+		entry.CollectorNumber = match[3]
+	}
+
+	entry.Name = strings.TrimSpace(line)
+	if entry.Name == "" || entry.Quantity <= 0 {
+		return Entry{}, errors.New("invalid entry")
+	}
+
+	return entry, nil
+}
+
+// dekFile is the root element of an MTGO .dek file.
+type dekFile struct {
+	XMLName              xml.Name  `xml:"Deck"`
+	XSD                  string    `xml:"xmlns:xsd,attr,omitempty"`
+	XSI                  string    `xml:"xmlns:xsi,attr,omitempty"`
+	NetDeckID            int       `xml:"NetDeckID"`
+	PreconstructedDeckID int       `xml:"PreconstructedDeckID"`
+	Cards                []dekCard `xml:"Cards"`
+}
+
+// dekCard is a single entry in an MTGO .dek file.
+type dekCard struct {
+	CatID      int    `xml:"CatID,attr"`
+	Quantity   int    `xml:"Quantity,attr"`
+	Sideboard  bool   `xml:"Sideboard,attr"`
+	Name       string `xml:"Name,attr"`
+	Annotation int    `xml:"Annotation,attr"`
+}
+
+func parseDek(contents []byte) (*Deck, error) {
+	var dek dekFile
+
+	if err := xml.Unmarshal(contents, &dek); err != nil {
+		return nil, fmt.Errorf("failed to parse .dek file: %w", err)
+	}
+
+	deck := &Deck{}
+
+	for _, card := range dek.Cards {
+		section := SectionMain
+		if card.Sideboard {
+			section = SectionSideboard
+		}
+
+		deck.Add(section, Entry{
+			Quantity: card.Quantity,
+			Name:     card.Name,
+			MTGOID:   card.CatID,
+		})
+	}
+
+	return deck, nil
+}
@@ -0,0 +1,139 @@
+package decklist_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/SethCurry/gofall/decklist"
+)
+
+func TestParse_Arena(t *testing.T) {
+	t.Parallel()
+
+	list := `About
+Name Sliver Test
+
+Commander
+1 Sliver Overlord (SCG) 139
+
+Deck
+4 Fury Sliver (TSP) 157
+2 Kor Outfitter (ZEN) 21
+2 Fury Sliver (TSP) 157
+
+Sideboard
+3 Web (3ED) 229
+`
+
+	deck, err := decklist.ParseString(list, decklist.FormatArena)
+	if err != nil {
+		t.Fatalf("failed to parse deck list: %v", err)
+	}
+
+	if deck.Name != "Sliver Test" {
+		t.Errorf("unexpected deck name %q", deck.Name)
+	}
+
+	if len(deck.Commander) != 1 || deck.Commander[0].Name != "Sliver Overlord" {
+		t.Errorf("unexpected commander: %+v", deck.Commander)
+	}
+
+	if len(deck.Main) != 2 {
+		t.Fatalf("expected 2 main deck entries, got %+v", deck.Main)
+	}
+
+	first := deck.Main[0]
+	if first.Quantity != 6 || first.Name != "Fury Sliver" || first.SetCode != "tsp" || first.CollectorNumber != "157" {
+		t.Errorf("unexpected first entry: %+v", first)
+	}
+
+	if deck.Count(decklist.SectionSideboard) != 3 {
+		t.Errorf("expected 3 sideboard cards, got %d", deck.Count(decklist.SectionSideboard))
+	}
+}
+
+func TestParse_MTGO(t *testing.T) {
+	t.Parallel()
+
+	list := "4 Fury Sliver\n20 Mountain\n\n2 Web\nSB: 1 Surge of Brilliance\n"
+
+	deck, err := decklist.ParseString(list, decklist.FormatMTGO)
+	if err != nil {
+		t.Fatalf("failed to parse deck list: %v", err)
+	}
+
+	if deck.Count(decklist.SectionMain) != 24 {
+		t.Errorf("expected 24 main deck cards, got %d", deck.Count(decklist.SectionMain))
+	}
+
+	if deck.Count(decklist.SectionSideboard) != 3 {
+		t.Errorf("expected 3 sideboard cards, got %d", deck.Count(decklist.SectionSideboard))
+	}
+}
+
+func TestParse_Moxfield(t *testing.T) {
+	t.Parallel()
+
+	list := "1 Sol Ring (C21) 263 *F*\n1 Obyra's Attendants // Desperate Parry (WOE) 63\n\nSIDEBOARD:\n1 Web (3ED) 229\n\nCOMMANDER:\n1 Sliver Overlord (SCG) 139\n\nMAYBEBOARD:\n1 Mystic Skyfish\n"
+
+	deck, err := decklist.ParseString(list, decklist.FormatAuto)
+	if err != nil {
+		t.Fatalf("failed to parse deck list: %v", err)
+	}
+
+	if len(deck.Main) != 2 || !deck.Main[0].Foil || deck.Main[0].SetCode != "c21" {
+		t.Errorf("unexpected main deck: %+v", deck.Main)
+	}
+
+	if deck.Main[1].Name != "Obyra's Attendants // Desperate Parry" {
+		t.Errorf("unexpected split card name %q", deck.Main[1].Name)
+	}
+
+	if len(deck.Sideboard) != 1 || len(deck.Commander) != 1 {
+		t.Errorf("unexpected sections: %+v", deck)
+	}
+
+	if deck.Count(decklist.SectionMain)+deck.Count(decklist.SectionSideboard)+deck.Count(decklist.SectionCommander) != 4 {
+		t.Errorf("expected maybeboard to be skipped")
+	}
+}
+
+func TestParse_MTGODek(t *testing.T) {
+	t.Parallel()
+
+	list := `<?xml version="1.0" encoding="utf-8"?>
+<Deck xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+  <NetDeckID>0</NetDeckID>
+  <PreconstructedDeckID>0</PreconstructedDeckID>
+  <Cards CatID="25527" Quantity="4" Sideboard="false" Name="Fury Sliver" Annotation="0" />
+  <Cards CatID="12345" Quantity="2" Sideboard="true" Name="Web" Annotation="0" />
+</Deck>`
+
+	deck, err := decklist.ParseString(list, decklist.FormatAuto)
+	if err != nil {
+		t.Fatalf("failed to parse deck list: %v", err)
+	}
+
+	if len(deck.Main) != 1 || deck.Main[0].MTGOID != 25527 || deck.Main[0].Quantity != 4 {
+		t.Errorf("unexpected main deck: %+v", deck.Main)
+	}
+
+	if len(deck.Sideboard) != 1 || deck.Sideboard[0].Name != "Web" {
+		t.Errorf("unexpected sideboard: %+v", deck.Sideboard)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := decklist.ParseString("4 Fury Sliver\n0 Web\n", decklist.FormatPlain)
+
+	var parseErr *decklist.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Line != 2 {
+		t.Errorf("expected a ParseError for line 2, got %v", err)
+	}
+
+	if _, err := decklist.ParseString("", decklist.Format("unknown")); !errors.Is(err, decklist.ErrUnknownFormat) {
+		t.Errorf("expected ErrUnknownFormat, got %v", err)
+	}
+}
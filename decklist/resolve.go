@@ -0,0 +1,240 @@
+package decklist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/SethCurry/gofall"
+)
+
+// Resolver finds the card each deck list entry refers to.
+type Resolver interface {
+	// Resolve sets Card on every entry it can find a card for.
+	// Entries it cannot find are left unchanged.
+	Resolve(ctx context.Context, entries []*Entry) error
+}
+
+// UnresolvedError is returned by Resolve when some entries could not be resolved.
+// The entries that could be resolved are still updated.
+type UnresolvedError struct {
+	// Names are the names of the entries that could not be resolved.
+	Names []string
+}
+
+func (u *UnresolvedError) Error() string {
+	return "failed to resolve cards: " + strings.Join(u.Names, ", ")
+}
+
+// Resolve sets Card on every entry of the deck that does not have one yet,
+// using the resolver.  If some entries could not be resolved, it returns
+// an *UnresolvedError.
+func Resolve(ctx context.Context, deck *Deck, resolver Resolver) error {
+	var entries []*Entry
+
+	for _, section := range AllSections() {
+		sectionEntries := *deck.Section(section)
+
+		for i := range sectionEntries {
+			if sectionEntries[i].Card == nil {
+				entries = append(entries, &sectionEntries[i])
+			}
+		}
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	if err := resolver.Resolve(ctx, entries); err != nil {
+		return err
+	}
+
+	var unresolved UnresolvedError
+
+	for _, entry := range entries {
+		if entry.Card == nil {
+			unresolved.Names = append(unresolved.Names, entry.Name)
+		}
+	}
+
+	if len(unresolved.Names) > 0 {
+		return &unresolved
+	}
+
+	return nil
+}
+
+// matches reports whether card is the printing the entry refers to.  Entries
+// without a printing match any printing with the same name, including by the
+// name of a single face, as Arena lists double-faced cards by their front face.
+func (e Entry) matches(card *gofall.Card) bool {
+	if e.MTGOID != 0 && (card.MTGOID == e.MTGOID || card.MTGOFoilID == e.MTGOID) {
+		return true
+	}
+
+	if e.SetCode != "" && !strings.EqualFold(e.SetCode, card.SetCode) {
+		return false
+	}
+
+	if e.SetCode != "" && e.CollectorNumber != "" {
+		return e.CollectorNumber == card.CollectorNumber
+	}
+
+	for _, name := range cardNames(card) {
+		if strings.EqualFold(name, e.Name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cardNames returns the full name of the card and the names of each of its faces.
+func cardNames(card *gofall.Card) []string {
+	names := []string{card.Name}
+
+	if faces := strings.Split(card.Name, " // "); len(faces) > 1 {
+		names = append(names, faces...)
+	}
+
+	return names
+}
+
+// identifier returns the identifier to look up the entry with in a collection request.
+func (e Entry) identifier() gofall.CardIdentifier {
+	switch {
+	case e.MTGOID != 0:
+		return gofall.CardIdentifier{MTGOID: e.MTGOID}
+	case e.SetCode != "" && e.CollectorNumber != "":
+		return gofall.CardIdentifier{Set: e.SetCode, CollectorNumber: e.CollectorNumber}
+	default:
+		return gofall.CardIdentifier{Name: e.Name, Set: e.SetCode}
+	}
+}
+
+// CollectionResolver resolves entries with Scryfall's collection endpoint,
+// using as few requests as possible.
+type CollectionResolver struct {
+	Client *gofall.CardClient
+}
+
+// Resolve implements the Resolver interface.
+func (c CollectionResolver) Resolve(ctx context.Context, entries []*Entry) error {
+	var identifiers []gofall.CardIdentifier
+
+	seen := map[gofall.CardIdentifier]bool{}
+
+	for _, entry := range entries {
+		identifier := entry.identifier()
+		if !seen[identifier] {
+			seen[identifier] = true
+			identifiers = append(identifiers, identifier)
+		}
+	}
+
+	cards, err := c.Client.Collection(ctx, identifiers)
+	if err != nil {
+		return fmt.Errorf("failed to get card collection: %w", err)
+	}
+
+	for _, entry := range entries {
+		for i := range cards {
+			if entry.matches(&cards[i]) {
+				entry.Card = &cards[i]
+
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// Index is an in-memory index of cards, typically built from a bulk data
+// export, that can resolve entries without any requests to Scryfall.
+type Index struct {
+	byName     map[string]*gofall.Card
+	byPrinting map[string]*gofall.Card
+	byMTGOID   map[int]*gofall.Card
+}
+
+// NewIndex creates an empty Index.
+func NewIndex() *Index {
+	return &Index{
+		byName:     map[string]*gofall.Card{},
+		byPrinting: map[string]*gofall.Card{},
+		byMTGOID:   map[int]*gofall.Card{},
+	}
+}
+
+// NewIndexFromReader creates an Index containing every card from reader,
+// e.g. a *gofall.BulkReader[gofall.Card].
+func NewIndexFromReader(reader interface{ Next() (*gofall.Card, error) }) (*Index, error) {
+	index := NewIndex()
+
+	for {
+		card, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return index, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read next card: %w", err)
+		}
+
+		index.Add(card)
+	}
+}
+
+func printingKey(setCode, collectorNumber string) string {
+	return strings.ToLower(setCode) + "/" + collectorNumber
+}
+
+// Add adds a card to the index.  When several printings share a name,
+// the first one added is used for entries without a printing.
+func (i *Index) Add(card *gofall.Card) {
+	for _, name := range cardNames(card) {
+		key := strings.ToLower(name)
+		if _, ok := i.byName[key]; !ok {
+			i.byName[key] = card
+		}
+	}
+
+	i.byPrinting[printingKey(card.SetCode, card.CollectorNumber)] = card
+
+	if card.MTGOID != 0 {
+		i.byMTGOID[card.MTGOID] = card
+	}
+
+	if card.MTGOFoilID != 0 {
+		i.byMTGOID[card.MTGOFoilID] = card
+	}
+}
+
+// Lookup returns the card the entry refers to, or nil if it is not in the index.
+// Entries whose printing is not in the index fall back to any printing by name.
+func (i *Index) Lookup(entry Entry) *gofall.Card {
+	if card, ok := i.byMTGOID[entry.MTGOID]; ok && entry.MTGOID != 0 {
+		return card
+	}
+
+	if entry.SetCode != "" && entry.CollectorNumber != "" {
+		if card, ok := i.byPrinting[printingKey(entry.SetCode, entry.CollectorNumber)]; ok {
+			return card
+		}
+	}
+
+	return i.byName[strings.ToLower(entry.Name)]
+}
+
+// Resolve implements the Resolver interface.
+func (i *Index) Resolve(_ context.Context, entries []*Entry) error {
+	for _, entry := range entries {
+		entry.Card = i.Lookup(*entry)
+	}
+
+	return nil
+}
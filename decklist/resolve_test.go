@@ -0,0 +1,63 @@
+package decklist_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/SethCurry/gofall"
+	"github.com/SethCurry/gofall/decklist"
+)
+
+func loadIndex(t *testing.T) *decklist.Index {
+	t.Helper()
+
+	testFd, err := os.Open("../test/cards.json")
+	if err != nil {
+		t.Fatalf("failed to open test cards file: %v", err)
+	}
+
+	defer testFd.Close()
+
+	reader, err := gofall.NewBulkReader[gofall.Card](testFd)
+	if err != nil {
+		t.Fatalf("failed to create bulk reader: %v", err)
+	}
+
+	index, err := decklist.NewIndexFromReader(reader)
+	if err != nil {
+		t.Fatalf("failed to build index: %v", err)
+	}
+
+	return index
+}
+
+func TestResolve_Index(t *testing.T) {
+	t.Parallel()
+
+	deck, err := decklist.ParseString("4 Fury Sliver (TSP) 157\n2 kor outfitter\n1 Desperate Parry\n1 Not A Card\n", decklist.FormatPlain)
+	if err != nil {
+		t.Fatalf("failed to parse deck list: %v", err)
+	}
+
+	err = decklist.Resolve(context.Background(), deck, loadIndex(t))
+
+	var unresolved *decklist.UnresolvedError
+	if !errors.As(err, &unresolved) || len(unresolved.Names) != 1 || unresolved.Names[0] != "Not A Card" {
+		t.Fatalf("expected Not A Card to be unresolved, got %v", err)
+	}
+
+	expected := []string{"Fury Sliver", "Kor Outfitter", "Obyra's Attendants // Desperate Parry"}
+
+	for i, name := range expected {
+		card := deck.Main[i].Card
+		if card == nil || card.Name != name {
+			t.Errorf("entry %d resolved to %v, expected %q", i, card, name)
+		}
+	}
+
+	if deck.Main[3].Card != nil {
+		t.Errorf("expected unresolved entry to have no card")
+	}
+}
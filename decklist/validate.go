@@ -0,0 +1,493 @@
+package decklist
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/SethCurry/gofall"
+	"github.com/SethCurry/gofall/commander"
+)
+
+// ViolationKind is the rule a deck breaks.
+type ViolationKind string
+
+const (
+	// ViolationUnresolved is an entry without a Card, which cannot be checked.
+	ViolationUnresolved ViolationKind = "unresolved"
+
+	// ViolationNotLegal is a card that is not legal in the format.
+	ViolationNotLegal ViolationKind = "not_legal"
+
+	// ViolationBanned is a card that is banned in the format.
+	ViolationBanned ViolationKind = "banned"
+
+	// ViolationRestricted is more than one copy of a restricted card, or a
+	// restricted card outside of the command zone in Pauper Commander.
+	ViolationRestricted ViolationKind = "restricted"
+
+	// ViolationTooManyCopies is more copies of a card than the format allows.
+	ViolationTooManyCopies ViolationKind = "too_many_copies"
+
+	// ViolationDeckSize is a deck with too few or too many cards.
+	ViolationDeckSize ViolationKind = "deck_size"
+
+	// ViolationSideboardSize is a sideboard with too many cards.
+	ViolationSideboardSize ViolationKind = "sideboard_size"
+
+	// ViolationNoCommander is a deck for a commander format without a commander.
+	ViolationNoCommander ViolationKind = "no_commander"
+
+	// ViolationColorIdentity is a card outside of the commander's color identity.
+	ViolationColorIdentity ViolationKind = "color_identity"
+
+	// ViolationInvalidCommander is a card that cannot be a commander, or a
+	// pair of commanders that cannot be paired.
+	ViolationInvalidCommander ViolationKind = "invalid_commander"
+
+	// ViolationCompanion is a deck that does not meet its companion's
+	// deck building restriction.
+	ViolationCompanion ViolationKind = "companion"
+)
+
+// Violation is a single way in which a deck breaks the rules of a format.
+type Violation struct {
+	Kind ViolationKind
+
+	// Section is the section of the offending card.  It is empty for
+	// violations about the deck as a whole, or a card in several sections.
+	Section Section
+
+	// CardName is the name of the offending card.  It is empty for
+	// violations about the deck as a whole.
+	CardName string
+
+	// Card is the offending card, if it was resolved.
+	Card *gofall.Card
+
+	// Message is a human-readable description of the violation.
+	Message string
+}
+
+func (v Violation) String() string {
+	return v.Message
+}
+
+// formatRules are the deck construction rules of a format.
+type formatRules struct {
+	// minDeck is the minimum number of cards in the deck, including commanders.
+	minDeck int
+
+	// maxDeck is the maximum number of cards in the deck, including
+	// commanders.  Zero means there is no maximum.
+	maxDeck int
+
+	// maxSideboard is the maximum number of cards in the sideboard,
+	// including any companion.
+	maxSideboard int
+
+	// maxCopies is the maximum number of copies of each card across the
+	// deck and sideboard, excluding basic lands.
+	maxCopies int
+
+	// commander is set for formats led by a commander, which require one
+	// and restrict the deck to its color identity.
+	commander bool
+}
+
+var (
+	constructedRules = formatRules{minDeck: 60, maxSideboard: 15, maxCopies: 4}
+	commanderRules   = formatRules{minDeck: 100, maxDeck: 100, maxCopies: 1, commander: true}
+)
+
+func rulesFor(format gofall.Format) (formatRules, error) {
+	switch format {
+	case gofall.FormatStandard, gofall.FormatFuture, gofall.FormatHistoric, gofall.FormatPioneer,
+		gofall.FormatExplorer, gofall.FormatModern, gofall.FormatLegacy, gofall.FormatPauper,
+		gofall.FormatVintage, gofall.FormatPenny, gofall.FormatAlchemy, gofall.FormatOldSchool,
+		gofall.FormatPreModern:
+		return constructedRules, nil
+	case gofall.FormatGladiator:
+		return formatRules{minDeck: 100, maxDeck: 100, maxCopies: 1}, nil
+	case gofall.FormatCommander, gofall.FormatDuel, gofall.FormatPrEDH, gofall.FormatPauperCommander,
+		gofall.FormatHistoricBrawl:
+		return commanderRules, nil
+	case gofall.FormatBrawl, gofall.FormatOathbreaker:
+		return formatRules{minDeck: 60, maxDeck: 60, maxCopies: 1, commander: true}, nil
+	default:
+		return formatRules{}, fmt.Errorf("%w: %s", gofall.ErrUnknownFormat, format)
+	}
+}
+
+// anyNumberPattern matches the Oracle text of cards like Relentless Rats.
+var anyNumberPattern = regexp.MustCompile(`(?i)a deck can have any number of cards named`)
+
+// upToPattern matches the Oracle text of cards like Seven Dwarves.
+var upToPattern = regexp.MustCompile(`(?i)a deck can have up to (\w+) cards named`)
+
+var numberWords = map[string]int{
+	"one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+}
+
+// isBasicLand reports whether the card is a basic land, which decks may
+// contain any number of.
+func isBasicLand(card *gofall.Card) bool {
+	front := card.ParsedTypeLine().Front()
+
+	return front.IsBasic() && front.IsLand()
+}
+
+// maxCopiesOf returns the maximum number of copies of card allowed, or -1
+// if any number is allowed.
+func maxCopiesOf(card *gofall.Card, rules formatRules) int {
+	if isBasicLand(card) || anyNumberPattern.MatchString(card.FrontOracleText()) {
+		return -1
+	}
+
+	if match := upToPattern.FindStringSubmatch(card.FrontOracleText()); match != nil {
+		if n, ok := numberWords[strings.ToLower(match[1])]; ok {
+			return n
+		}
+	}
+
+	return rules.maxCopies
+}
+
+// cardCount is the number of copies of a card across sections.
+type cardCount struct {
+	card     *gofall.Card
+	quantity int
+	sections map[Section]bool
+}
+
+// Validate checks the deck against the construction rules of a format and
+// returns every violation found, or none if the deck is legal.  The deck must
+// be resolved with Resolve first; entries without a Card are reported as
+// ViolationUnresolved and otherwise ignored.
+//
+// It returns an error wrapping gofall.ErrUnknownFormat if the format is not known.
+func Validate(deck *Deck, format gofall.Format) ([]Violation, error) {
+	rules, err := rulesFor(format)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []Violation
+
+	counts := map[string]*cardCount{}
+
+	var names []string
+
+	for _, section := range AllSections() {
+		for _, entry := range *deck.Section(section) {
+			if entry.Card == nil {
+				violations = append(violations, Violation{
+					Kind:     ViolationUnresolved,
+					Section:  section,
+					CardName: entry.Name,
+					Message:  fmt.Sprintf("%s could not be resolved to a card", entry.Name),
+				})
+
+				continue
+			}
+
+			count, ok := counts[entry.Card.Name]
+			if !ok {
+				count = &cardCount{card: entry.Card, sections: map[Section]bool{}}
+				counts[entry.Card.Name] = count
+				names = append(names, entry.Card.Name)
+			}
+
+			count.quantity += entry.Quantity
+			count.sections[section] = true
+		}
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		violations = append(violations, checkCard(counts[name], format, rules)...)
+	}
+
+	violations = append(violations, checkSizes(deck, rules)...)
+
+	if rules.commander {
+		if format != gofall.FormatOathbreaker {
+			violations = append(violations, checkCommanders(deck, format)...)
+		}
+
+		violations = append(violations, checkColorIdentity(deck)...)
+	}
+
+	violations = append(violations, checkCompanion(deck, rules)...)
+
+	return violations, nil
+}
+
+// checkCard checks the legality and number of copies of a single card.
+func checkCard(count *cardCount, format gofall.Format, rules formatRules) []Violation {
+	var violations []Violation
+
+	card := count.card
+
+	newViolation := func(kind ViolationKind, message string) Violation {
+		violation := Violation{Kind: kind, CardName: card.Name, Card: card, Message: message}
+
+		if len(count.sections) == 1 {
+			for section := range count.sections {
+				violation.Section = section
+			}
+		}
+
+		return violation
+	}
+
+	switch card.Legality.Get(format) {
+	case gofall.LegalityBanned:
+		violations = append(violations, newViolation(ViolationBanned,
+			fmt.Sprintf("%s is banned in %s", card.Name, format)))
+	case gofall.LegalityNotLegal:
+		violations = append(violations, newViolation(ViolationNotLegal,
+			fmt.Sprintf("%s is not legal in %s", card.Name, format)))
+	case gofall.LegalityRestricted:
+		if format == gofall.FormatPauperCommander {
+			// Restricted cards may only be the commander in Pauper Commander.
+			if len(count.sections) != 1 || !count.sections[SectionCommander] {
+				violations = append(violations, newViolation(ViolationRestricted,
+					fmt.Sprintf("%s can only be a commander in %s", card.Name, format)))
+			}
+		} else if count.quantity > 1 {
+			violations = append(violations, newViolation(ViolationRestricted,
+				fmt.Sprintf("%s is restricted in %s, but the deck has %d copies", card.Name, format, count.quantity)))
+		}
+	case gofall.LegalityLegal:
+	}
+
+	if maxCopies := maxCopiesOf(card, rules); maxCopies >= 0 && count.quantity > maxCopies {
+		violations = append(violations, newViolation(ViolationTooManyCopies,
+			fmt.Sprintf("the deck has %d copies of %s, but at most %d are allowed", count.quantity, card.Name, maxCopies)))
+	}
+
+	return violations
+}
+
+// checkSizes checks the number of cards in the deck and sideboard,
+// and that commander formats have a commander.
+func checkSizes(deck *Deck, rules formatRules) []Violation {
+	var violations []Violation
+
+	deckSize := deck.Count(SectionMain) + deck.Count(SectionCommander)
+
+	if deckSize < rules.minDeck {
+		violations = append(violations, Violation{
+			Kind:    ViolationDeckSize,
+			Message: fmt.Sprintf("the deck has %d cards, but at least %d are required", deckSize, rules.minDeck),
+		})
+	}
+
+	if rules.maxDeck > 0 && deckSize > rules.maxDeck {
+		violations = append(violations, Violation{
+			Kind:    ViolationDeckSize,
+			Message: fmt.Sprintf("the deck has %d cards, but at most %d are allowed", deckSize, rules.maxDeck),
+		})
+	}
+
+	// Companions are kept outside of the game in commander formats,
+	// but take a sideboard slot elsewhere.
+	sideboardSize := deck.Count(SectionSideboard)
+	if !rules.commander {
+		sideboardSize += deck.Count(SectionCompanion)
+	}
+
+	if sideboardSize > rules.maxSideboard {
+		violations = append(violations, Violation{
+			Kind:    ViolationSideboardSize,
+			Section: SectionSideboard,
+			Message: fmt.Sprintf("the sideboard has %d cards, but at most %d are allowed", sideboardSize, rules.maxSideboard),
+		})
+	}
+
+	if rules.commander && len(deck.Commander) == 0 {
+		violations = append(violations, Violation{
+			Kind:    ViolationNoCommander,
+			Section: SectionCommander,
+			Message: "the deck has no commander",
+		})
+	}
+
+	return violations
+}
+
+// resolvedCards returns the resolved cards of a section, or false if
+// any of them is unresolved.
+func resolvedCards(entries []Entry) ([]*gofall.Card, bool) {
+	cards := make([]*gofall.Card, 0, len(entries))
+
+	for _, entry := range entries {
+		if entry.Card == nil {
+			return nil, false
+		}
+
+		cards = append(cards, entry.Card)
+	}
+
+	return cards, true
+}
+
+// canLeadPauper reports whether the card can be a commander in Pauper
+// Commander, where any creature or Background whose legality is
+// restricted can be a commander, legendary or not.
+func canLeadPauper(card *gofall.Card) bool {
+	if card.Legality.Get(gofall.FormatPauperCommander) != gofall.LegalityRestricted {
+		return false
+	}
+
+	return card.ParsedTypeLine().Front().IsCreature() || commander.IsBackground(card)
+}
+
+// checkCommanders checks that the commanders can lead a deck together.
+func checkCommanders(deck *Deck, format gofall.Format) []Violation {
+	commanders, ok := resolvedCards(deck.Commander)
+	if !ok || len(commanders) == 0 {
+		return nil
+	}
+
+	if len(commanders) > 2 {
+		return []Violation{{
+			Kind:    ViolationInvalidCommander,
+			Section: SectionCommander,
+			Message: fmt.Sprintf("the deck has %d commanders, but at most 2 are allowed", len(commanders)),
+		}}
+	}
+
+	canLead := commander.CanBeCommander
+	if format == gofall.FormatPauperCommander {
+		canLead = canLeadPauper
+	}
+
+	var violations []Violation
+
+	for _, card := range commanders {
+		if !canLead(card) || (len(commanders) == 1 && commander.IsBackground(card)) {
+			violations = append(violations, Violation{
+				Kind:     ViolationInvalidCommander,
+				Section:  SectionCommander,
+				CardName: card.Name,
+				Card:     card,
+				Message:  fmt.Sprintf("%s cannot be a commander", card.Name),
+			})
+		}
+	}
+
+	if len(violations) > 0 || len(commanders) == 1 {
+		return violations
+	}
+
+	if _, ok := commander.CanPair(commanders[0], commanders[1]); !ok {
+		violations = append(violations, Violation{
+			Kind:    ViolationInvalidCommander,
+			Section: SectionCommander,
+			Message: fmt.Sprintf("%s and %s cannot be paired as commanders", commanders[0].Name, commanders[1].Name),
+		})
+	}
+
+	return violations
+}
+
+// checkColorIdentity checks that every card is within the combined
+// color identity of the deck's commanders.
+func checkColorIdentity(deck *Deck) []Violation {
+	commanders, ok := resolvedCards(deck.Commander)
+	if !ok || len(commanders) == 0 {
+		// The identity is unknown, so any check could be wrong.
+		return nil
+	}
+
+	identity := commander.ColorIdentity(commanders...)
+
+	var violations []Violation
+
+	for _, section := range []Section{SectionMain, SectionSideboard, SectionCompanion} {
+		for _, entry := range *deck.Section(section) {
+			if entry.Card == nil || commander.WithinIdentity(entry.Card, identity) {
+				continue
+			}
+
+			violations = append(violations, Violation{
+				Kind:     ViolationColorIdentity,
+				Section:  section,
+				CardName: entry.Card.Name,
+				Card:     entry.Card,
+				Message: fmt.Sprintf(
+					"%s is outside of the commander's color identity %s",
+					entry.Card.Name, strings.Join(identity, ""),
+				),
+			})
+		}
+	}
+
+	return violations
+}
+
+// checkCompanion checks the deck against its companion's restriction.
+// The starting deck includes the commanders and, outside of commander
+// formats, the sideboard.
+func checkCompanion(deck *Deck, rules formatRules) []Violation {
+	if len(deck.Companion) != 1 || deck.Companion[0].Card == nil {
+		return nil
+	}
+
+	companion := deck.Companion[0].Card
+
+	var startingDeck []*gofall.Card
+
+	for _, section := range []Section{SectionCommander, SectionMain, SectionSideboard} {
+		if section == SectionSideboard && rules.commander {
+			continue
+		}
+
+		for _, entry := range *deck.Section(section) {
+			if entry.Card == nil {
+				continue
+			}
+
+			for i := 0; i < entry.Quantity; i++ {
+				startingDeck = append(startingDeck, entry.Card)
+			}
+		}
+	}
+
+	result, err := commander.CheckCompanion(companion, startingDeck, rules.minDeck)
+	if err != nil || result.Satisfied {
+		return nil
+	}
+
+	violations := []Violation{{
+		Kind:     ViolationCompanion,
+		Section:  SectionCompanion,
+		CardName: companion.Name,
+		Card:     companion,
+		Message:  fmt.Sprintf("the deck does not meet the restriction of %s: %s", companion.Name, result.Reason),
+	}}
+
+	seen := map[string]bool{}
+
+	for _, card := range result.Offending {
+		if seen[card.Name] {
+			continue
+		}
+
+		seen[card.Name] = true
+
+		violations = append(violations, Violation{
+			Kind:     ViolationCompanion,
+			CardName: card.Name,
+			Card:     card,
+			Message:  fmt.Sprintf("%s breaks the restriction of %s", card.Name, companion.Name),
+		})
+	}
+
+	return violations
+}
@@ -0,0 +1,233 @@
+package decklist_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/SethCurry/gofall"
+	"github.com/SethCurry/gofall/decklist"
+)
+
+func legalCard(name, typeLine string, colorIdentity ...string) *gofall.Card {
+	card := &gofall.Card{Name: name, TypeLine: typeLine, ColorIdentity: colorIdentity}
+
+	for _, format := range gofall.AllFormats() {
+		_ = card.Legality.Set(format, gofall.LegalityLegal)
+	}
+
+	return card
+}
+
+func violationKinds(violations []decklist.Violation) map[decklist.ViolationKind][]string {
+	kinds := map[decklist.ViolationKind][]string{}
+
+	for _, violation := range violations {
+		kinds[violation.Kind] = append(kinds[violation.Kind], violation.CardName)
+	}
+
+	return kinds
+}
+
+func TestValidate_Constructed(t *testing.T) {
+	t.Parallel()
+
+	bolt := legalCard("Lightning Bolt", "Instant", "R")
+	mountain := legalCard("Mountain", "Basic Land — Mountain", "R")
+	rats := legalCard("Relentless Rats", "Creature — Rat", "B")
+	rats.OracleText = "Relentless Rats gets +1/+1 for each other creature you control named Relentless Rats.\n" +
+		"A deck can have any number of cards named Relentless Rats."
+	dwarves := legalCard("Seven Dwarves", "Creature — Dwarf", "R")
+	dwarves.OracleText = "A deck can have up to seven cards named Seven Dwarves."
+	lotus := legalCard("Black Lotus", "Artifact")
+	lotus.Legality.Vintage = gofall.LegalityRestricted
+	ritual := legalCard("Dark Ritual", "Instant", "B")
+	ritual.Legality.Vintage = gofall.LegalityBanned
+
+	deck := &decklist.Deck{
+		Main: []decklist.Entry{
+			{Quantity: 4, Name: bolt.Name, Card: bolt},
+			{Quantity: 24, Name: mountain.Name, Card: mountain},
+			{Quantity: 20, Name: rats.Name, Card: rats},
+			{Quantity: 8, Name: dwarves.Name, Card: dwarves},
+			{Quantity: 2, Name: lotus.Name, Card: lotus},
+			{Quantity: 1, Name: ritual.Name, Card: ritual},
+			{Quantity: 1, Name: "Unknown Card"},
+		},
+		Sideboard: []decklist.Entry{
+			{Quantity: 1, Name: bolt.Name, Card: bolt},
+			{Quantity: 15, Name: mountain.Name, Card: mountain},
+		},
+	}
+
+	violations, err := decklist.Validate(deck, gofall.FormatVintage)
+	if err != nil {
+		t.Fatalf("failed to validate deck: %v", err)
+	}
+
+	kinds := violationKinds(violations)
+
+	expected := map[decklist.ViolationKind][]string{
+		decklist.ViolationUnresolved:    {"Unknown Card"},
+		decklist.ViolationRestricted:    {"Black Lotus"},
+		decklist.ViolationBanned:        {"Dark Ritual"},
+		decklist.ViolationTooManyCopies: {"Lightning Bolt", "Seven Dwarves"},
+		decklist.ViolationSideboardSize: {""},
+	}
+
+	if len(kinds) != len(expected) {
+		t.Errorf("unexpected violations: %+v", violations)
+	}
+
+	for kind, names := range expected {
+		if len(kinds[kind]) != len(names) {
+			t.Errorf("unexpected %s violations: got %v, want %v", kind, kinds[kind], names)
+
+			continue
+		}
+
+		for i, name := range names {
+			if kinds[kind][i] != name {
+				t.Errorf("unexpected %s violation: got %q, want %q", kind, kinds[kind][i], name)
+			}
+		}
+	}
+}
+
+func TestValidate_Commander(t *testing.T) {
+	t.Parallel()
+
+	commander := legalCard("Krenko, Mob Boss", "Legendary Creature — Goblin Warrior", "R")
+	mountain := legalCard("Mountain", "Basic Land — Mountain", "R")
+	bolt := legalCard("Lightning Bolt", "Instant", "R")
+	counterspell := legalCard("Counterspell", "Instant", "U")
+
+	deck := &decklist.Deck{
+		Commander: []decklist.Entry{{Quantity: 1, Name: commander.Name, Card: commander}},
+		Main: []decklist.Entry{
+			{Quantity: 96, Name: mountain.Name, Card: mountain},
+			{Quantity: 2, Name: bolt.Name, Card: bolt},
+			{Quantity: 1, Name: counterspell.Name, Card: counterspell},
+		},
+	}
+
+	violations, err := decklist.Validate(deck, gofall.FormatCommander)
+	if err != nil {
+		t.Fatalf("failed to validate deck: %v", err)
+	}
+
+	kinds := violationKinds(violations)
+
+	if len(kinds) != 2 ||
+		len(kinds[decklist.ViolationTooManyCopies]) != 1 ||
+		len(kinds[decklist.ViolationColorIdentity]) != 1 ||
+		kinds[decklist.ViolationColorIdentity][0] != "Counterspell" {
+		t.Errorf("unexpected violations: %+v", violations)
+	}
+
+	deck.Commander = nil
+
+	violations, err = decklist.Validate(deck, gofall.FormatCommander)
+	if err != nil {
+		t.Fatalf("failed to validate deck: %v", err)
+	}
+
+	kinds = violationKinds(violations)
+	if len(kinds[decklist.ViolationNoCommander]) != 1 || len(kinds[decklist.ViolationDeckSize]) != 1 {
+		t.Errorf("expected missing commander and deck size violations, got %+v", violations)
+	}
+}
+
+func TestValidate_CommanderPairing(t *testing.T) {
+	t.Parallel()
+
+	bolt := legalCard("Lightning Bolt", "Instant", "R")
+	krenko := legalCard("Krenko, Mob Boss", "Legendary Creature — Goblin Warrior", "R")
+	krenko.CMC = 4
+	lurrus := legalCard("Lurrus of the Dream-Den", "Legendary Creature — Cat Nightmare", "W", "B")
+	mountain := legalCard("Mountain", "Basic Land — Mountain", "R")
+
+	deck := &decklist.Deck{
+		Commander: []decklist.Entry{{Quantity: 1, Name: bolt.Name, Card: bolt}},
+		Companion: []decklist.Entry{{Quantity: 1, Name: lurrus.Name, Card: lurrus}},
+		Main: []decklist.Entry{
+			{Quantity: 1, Name: krenko.Name, Card: krenko},
+			{Quantity: 98, Name: mountain.Name, Card: mountain},
+		},
+	}
+
+	violations, err := decklist.Validate(deck, gofall.FormatCommander)
+	if err != nil {
+		t.Fatalf("failed to validate deck: %v", err)
+	}
+
+	kinds := violationKinds(violations)
+
+	if len(kinds[decklist.ViolationInvalidCommander]) != 1 || kinds[decklist.ViolationInvalidCommander][0] != "Lightning Bolt" {
+		t.Errorf("expected Lightning Bolt to be an invalid commander, got %+v", violations)
+	}
+
+	// Lurrus is outside of the identity, and Krenko breaks its restriction.
+	if len(kinds[decklist.ViolationColorIdentity]) != 1 {
+		t.Errorf("expected a color identity violation for the companion, got %+v", violations)
+	}
+
+	companion := kinds[decklist.ViolationCompanion]
+	if len(companion) != 2 || companion[0] != "Lurrus of the Dream-Den" || companion[1] != "Krenko, Mob Boss" {
+		t.Errorf("unexpected companion violations: %v", companion)
+	}
+}
+
+func TestValidate_PauperCommander(t *testing.T) {
+	t.Parallel()
+
+	skyfisher := legalCard("Kor Skyfisher", "Creature — Kor Soldier", "W")
+	skyfisher.Legality.PauperCommander = gofall.LegalityRestricted
+	plains := legalCard("Plains", "Basic Land — Plains", "W")
+	krenko := legalCard("Krenko, Mob Boss", "Legendary Creature — Goblin Warrior", "R")
+	krenko.Legality.PauperCommander = gofall.LegalityNotLegal
+
+	deck := &decklist.Deck{
+		Commander: []decklist.Entry{{Quantity: 1, Name: skyfisher.Name, Card: skyfisher}},
+		Main:      []decklist.Entry{{Quantity: 99, Name: plains.Name, Card: plains}},
+	}
+
+	violations, err := decklist.Validate(deck, gofall.FormatPauperCommander)
+	if err != nil {
+		t.Fatalf("failed to validate deck: %v", err)
+	}
+
+	if len(violations) != 0 {
+		t.Errorf("expected an uncommon creature to be a valid commander, got %+v", violations)
+	}
+
+	// A restricted card can't be in the main deck, and a card that isn't
+	// restricted can't be the commander.
+	deck.Commander = []decklist.Entry{{Quantity: 1, Name: krenko.Name, Card: krenko}}
+	deck.Main = []decklist.Entry{
+		{Quantity: 98, Name: plains.Name, Card: plains},
+		{Quantity: 1, Name: skyfisher.Name, Card: skyfisher},
+	}
+
+	violations, err = decklist.Validate(deck, gofall.FormatPauperCommander)
+	if err != nil {
+		t.Fatalf("failed to validate deck: %v", err)
+	}
+
+	kinds := violationKinds(violations)
+
+	if len(kinds[decklist.ViolationInvalidCommander]) != 1 || kinds[decklist.ViolationInvalidCommander][0] != "Krenko, Mob Boss" {
+		t.Errorf("expected Krenko to be an invalid commander, got %+v", violations)
+	}
+
+	if len(kinds[decklist.ViolationRestricted]) != 1 || kinds[decklist.ViolationRestricted][0] != "Kor Skyfisher" {
+		t.Errorf("expected Kor Skyfisher to be restricted to the command zone, got %+v", violations)
+	}
+}
+
+func TestValidate_UnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	if _, err := decklist.Validate(&decklist.Deck{}, gofall.Format("unknown")); !errors.Is(err, gofall.ErrUnknownFormat) {
+		t.Errorf("expected ErrUnknownFormat, got %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+package decklist
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Export returns the deck list as a string in the given format.  See Write.
+func Export(deck *Deck, format Format) (string, error) {
+	var buf bytes.Buffer
+
+	if err := Write(&buf, deck, format); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// Write writes the deck list to w in the given format.
+//
+// Formats that cannot represent a section put its entries where that
+// format's users expect them: MTGO formats put commanders and companions in
+// the sideboard, and plain text lists them in the sideboard as well.
+func Write(w io.Writer, deck *Deck, format Format) error {
+	switch format {
+	case FormatArena:
+		return writeSections(w, deck, []sectionHeader{
+			{SectionCommander, "Commander"},
+			{SectionCompanion, "Companion"},
+			{SectionMain, "Deck"},
+			{SectionSideboard, "Sideboard"},
+		}, arenaLine)
+	case FormatMoxfield:
+		return writeSections(w, deck, []sectionHeader{
+			{SectionMain, ""},
+			{SectionSideboard, "SIDEBOARD:"},
+			{SectionCommander, "COMMANDER:"},
+			{SectionCompanion, "COMPANION:"},
+		}, moxfieldLine)
+	case FormatMTGO:
+		return writeMainAndSide(w, deck, mtgoLine)
+	case FormatPlain:
+		return writeMainAndSide(w, deck, arenaLine)
+	case FormatMTGODek:
+		return writeDek(w, deck)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+// sectionHeader is a section and the header line that introduces it.
+// Sections with an empty header are written without one.
+type sectionHeader struct {
+	section Section
+	header  string
+}
+
+func writeSections(w io.Writer, deck *Deck, sections []sectionHeader, line func(Entry) string) error {
+	var buf strings.Builder
+
+	for _, section := range sections {
+		entries := *deck.Section(section.section)
+		if len(entries) == 0 {
+			continue
+		}
+
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+
+		if section.header != "" {
+			buf.WriteString(section.header + "\n")
+		}
+
+		for _, entry := range entries {
+			buf.WriteString(line(entry) + "\n")
+		}
+	}
+
+	if _, err := io.WriteString(w, buf.String()); err != nil {
+		return fmt.Errorf("failed to write deck list: %w", err)
+	}
+
+	return nil
+}
+
+// writeMainAndSide writes the main deck, a blank line, and everything else
+// as the sideboard.
+func writeMainAndSide(w io.Writer, deck *Deck, line func(Entry) string) error {
+	var buf strings.Builder
+
+	for _, entry := range deck.Main {
+		buf.WriteString(line(entry) + "\n")
+	}
+
+	side := sideboardEntries(deck)
+	if len(side) > 0 {
+		buf.WriteString("\n")
+
+		for _, entry := range side {
+			buf.WriteString(line(entry) + "\n")
+		}
+	}
+
+	if _, err := io.WriteString(w, buf.String()); err != nil {
+		return fmt.Errorf("failed to write deck list: %w", err)
+	}
+
+	return nil
+}
+
+// sideboardEntries returns the entries that belong in the sideboard of
+// formats without commander or companion sections.
+func sideboardEntries(deck *Deck) []Entry {
+	var side []Entry
+
+	side = append(side, deck.Commander...)
+	side = append(side, deck.Companion...)
+	side = append(side, deck.Sideboard...)
+
+	return side
+}
+
+func mtgoLine(entry Entry) string {
+	return fmt.Sprintf("%d %s", entry.Quantity, entry.Name)
+}
+
+func arenaLine(entry Entry) string {
+	line := mtgoLine(entry)
+
+	setCode, collectorNumber := entry.printing()
+	if setCode != "" {
+		line += " (" + strings.ToUpper(setCode) + ")"
+
+		if collectorNumber != "" {
+			line += " " + collectorNumber
+		}
+	}
+
+	return line
+}
+
+func moxfieldLine(entry Entry) string {
+	line := arenaLine(entry)
+	if entry.Foil {
+		line += " *F*"
+	}
+
+	return line
+}
+
+// printing returns the set code and collector number of the entry,
+// falling back to those of the resolved card.
+func (e Entry) printing() (string, string) {
+	if e.SetCode != "" {
+		return e.SetCode, e.CollectorNumber
+	}
+
+	if e.Card != nil {
+		return e.Card.SetCode, e.Card.CollectorNumber
+	}
+
+	return "", ""
+}
+
+func writeDek(w io.Writer, deck *Deck) error {
+	dek := dekFile{
+		XSD: "http://www.w3.org/2001/XMLSchema",
+		XSI: "http://www.w3.org/2001/XMLSchema-instance",
+	}
+
+	addCards := func(entries []Entry, sideboard bool) {
+		for _, entry := range entries {
+			catID := entry.MTGOID
+			if catID == 0 && entry.Card != nil {
+				catID = entry.Card.MTGOID
+			}
+
+			dek.Cards = append(dek.Cards, dekCard{
+				CatID:     catID,
+				Quantity:  entry.Quantity,
+				Sideboard: sideboard,
+				Name:      entry.Name,
+			})
+		}
+	}
+
+	addCards(deck.Main, false)
+	addCards(sideboardEntries(deck), true)
+
+	marshalled, err := xml.MarshalIndent(dek, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal .dek file: %w", err)
+	}
+
+	if _, err := io.WriteString(w, xml.Header+string(marshalled)+"\n"); err != nil {
+		return fmt.Errorf("failed to write deck list: %w", err)
+	}
+
+	return nil
+}
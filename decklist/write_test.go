@@ -0,0 +1,92 @@
+package decklist_test
+
+import (
+	"testing"
+
+	"github.com/SethCurry/gofall/decklist"
+)
+
+func testDeck() *decklist.Deck {
+	return &decklist.Deck{
+		Commander: []decklist.Entry{{Quantity: 1, Name: "Sliver Overlord", SetCode: "scg", CollectorNumber: "139"}},
+		Main: []decklist.Entry{
+			{Quantity: 4, Name: "Fury Sliver", SetCode: "tsp", CollectorNumber: "157", Foil: true, MTGOID: 25527},
+			{Quantity: 2, Name: "Kor Outfitter"},
+		},
+		Sideboard: []decklist.Entry{{Quantity: 3, Name: "Web", SetCode: "3ed"}},
+	}
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		format decklist.Format
+		want   string
+	}{
+		{
+			format: decklist.FormatArena,
+			want:   "Commander\n1 Sliver Overlord (SCG) 139\n\nDeck\n4 Fury Sliver (TSP) 157\n2 Kor Outfitter\n\nSideboard\n3 Web (3ED)\n",
+		},
+		{
+			format: decklist.FormatMoxfield,
+			want:   "4 Fury Sliver (TSP) 157 *F*\n2 Kor Outfitter\n\nSIDEBOARD:\n3 Web (3ED)\n\nCOMMANDER:\n1 Sliver Overlord (SCG) 139\n",
+		},
+		{
+			format: decklist.FormatMTGO,
+			want:   "4 Fury Sliver\n2 Kor Outfitter\n\n1 Sliver Overlord\n3 Web\n",
+		},
+		{
+			format: decklist.FormatPlain,
+			want:   "4 Fury Sliver (TSP) 157\n2 Kor Outfitter\n\n1 Sliver Overlord (SCG) 139\n3 Web (3ED)\n",
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(string(v.format), func(t *testing.T) {
+			t.Parallel()
+
+			got, err := decklist.Export(testDeck(), v.format)
+			if err != nil {
+				t.Fatalf("failed to export deck: %v", err)
+			}
+
+			if got != v.want {
+				t.Errorf("unexpected export:\n%s\nwant:\n%s", got, v.want)
+			}
+		})
+	}
+}
+
+func TestWrite_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []decklist.Format{decklist.FormatArena, decklist.FormatMoxfield, decklist.FormatMTGODek} {
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+
+			exported, err := decklist.Export(testDeck(), format)
+			if err != nil {
+				t.Fatalf("failed to export deck: %v", err)
+			}
+
+			deck, err := decklist.ParseString(exported, format)
+			if err != nil {
+				t.Fatalf("failed to parse exported deck: %v\n%s", err, exported)
+			}
+
+			if deck.Count(decklist.SectionMain) != 6 {
+				t.Errorf("expected 6 main deck cards, got %d", deck.Count(decklist.SectionMain))
+			}
+
+			total := 0
+			for _, section := range decklist.AllSections() {
+				total += deck.Count(section)
+			}
+
+			if total != 10 {
+				t.Errorf("expected 10 cards in total, got %d", total)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// Package deckstats computes statistics about a deck of resolved cards,
+// such as its mana curve, color requirements and the odds of drawing
+// enough lands.
+package deckstats
+
+import (
+	"strings"
+
+	"github.com/SethCurry/gofall"
+	"github.com/SethCurry/gofall/decklist"
+)
+
+// CardCount is a number of copies of a card in a deck.
+type CardCount struct {
+	Card     *gofall.Card
+	Quantity int
+}
+
+// FromDeck returns the resolved cards of the given sections of a deck,
+// or of the main deck and commanders if no sections are given.
+// Unresolved entries are skipped.
+func FromDeck(deck *decklist.Deck, sections ...decklist.Section) []CardCount {
+	if len(sections) == 0 {
+		sections = []decklist.Section{decklist.SectionCommander, decklist.SectionMain}
+	}
+
+	var counts []CardCount
+
+	for _, section := range sections {
+		entries := deck.Section(section)
+		if entries == nil {
+			continue
+		}
+
+		for _, entry := range *entries {
+			if entry.Card != nil {
+				counts = append(counts, CardCount{Card: entry.Card, Quantity: entry.Quantity})
+			}
+		}
+	}
+
+	return counts
+}
+
+// Stats are statistics about a deck.  Every count is a number of cards,
+// so four copies of a card count four times.
+type Stats struct {
+	// Total is the number of cards in the deck.
+	Total int
+
+	// Lands is the number of land cards.
+	Lands int
+
+	// Nonlands is the number of nonland cards.
+	Nonlands int
+
+	// LandRatio is the fraction of the deck that is lands.
+	LandRatio float64
+
+	// AverageManaValue is the average mana value of the nonland cards.
+	AverageManaValue float64
+
+	// ManaCurve is the number of nonland cards at each mana value.
+	// Fractional mana values are rounded down.
+	ManaCurve map[int]int
+
+	// ColorPips is the number of colored mana symbols of each color in the
+	// mana costs of the cards, keyed by color, e.g. "R".  Hybrid symbols
+	// count towards both of their colors.  Colorless symbols count as "C".
+	// Only the front face of a multi-faced card is counted, as with
+	// Card.FrontManaCost.
+	ColorPips map[string]int
+
+	// Types is the number of cards of each card type, e.g. "Creature".
+	// A card with several types, such as an artifact creature, counts
+	// towards each of them.
+	Types map[string]int
+
+	// ManaSources is the number of cards that can produce each color of
+	// mana, keyed by color, including "C" for colorless.
+	ManaSources map[string]int
+}
+
+// pipColors are the mana symbol letters that are counted as pips.
+var pipColors = map[string]bool{"W": true, "U": true, "B": true, "R": true, "G": true, "C": true}
+
+// Compute calculates statistics for the given cards.
+func Compute(cards []CardCount) Stats {
+	stats := Stats{
+		ManaCurve:   map[int]int{},
+		ColorPips:   map[string]int{},
+		Types:       map[string]int{},
+		ManaSources: map[string]int{},
+	}
+
+	totalManaValue := 0.0
+
+	for _, count := range cards {
+		card := count.Card
+		quantity := count.Quantity
+
+		stats.Total += quantity
+
+		types := card.ParsedTypeLine().Front()
+		for _, cardType := range gofall.AllCardTypes() {
+			if types.HasType(cardType) {
+				stats.Types[cardType] += quantity
+			}
+		}
+
+		if types.IsLand() {
+			stats.Lands += quantity
+		} else {
+			stats.Nonlands += quantity
+			stats.ManaCurve[int(card.CMC)] += quantity
+			totalManaValue += float64(card.CMC) * float64(quantity)
+		}
+
+		for color, pips := range countPips(card.FrontManaCost()) {
+			stats.ColorPips[color] += pips * quantity
+		}
+
+		for _, color := range card.ProducedMana {
+			stats.ManaSources[color] += quantity
+		}
+	}
+
+	if stats.Total > 0 {
+		stats.LandRatio = float64(stats.Lands) / float64(stats.Total)
+	}
+
+	if stats.Nonlands > 0 {
+		stats.AverageManaValue = totalManaValue / float64(stats.Nonlands)
+	}
+
+	return stats
+}
+
+// countPips counts the colored mana symbols in a mana cost.
+func countPips(manaCost string) map[string]int {
+	pips := map[string]int{}
+
+	for _, symbol := range gofall.ManaSymbols(manaCost) {
+		for _, part := range strings.Split(symbol, "/") {
+			if pipColors[part] {
+				pips[part]++
+			}
+		}
+	}
+
+	return pips
+}
@@ -0,0 +1,162 @@
+package deckstats_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SethCurry/gofall"
+	"github.com/SethCurry/gofall/decklist"
+	"github.com/SethCurry/gofall/deckstats"
+)
+
+func testDeck() *decklist.Deck {
+	bolt := &gofall.Card{Name: "Lightning Bolt", TypeLine: "Instant", ManaCost: "{R}", CMC: 1}
+	helix := &gofall.Card{Name: "Lightning Helix", TypeLine: "Instant", ManaCost: "{R}{W}", CMC: 2}
+	golem := &gofall.Card{Name: "Bronze Golem", TypeLine: "Artifact Creature — Golem", ManaCost: "{5}", CMC: 5}
+	boros := &gofall.Card{Name: "Boros Reckoner", TypeLine: "Creature — Minotaur Wizard", ManaCost: "{R/W}{R/W}{R/W}", CMC: 3}
+	mountain := &gofall.Card{Name: "Mountain", TypeLine: "Basic Land — Mountain", ProducedMana: []string{"R"}}
+	sacred := &gofall.Card{Name: "Sacred Foundry", TypeLine: "Land — Mountain Plains", ProducedMana: []string{"R", "W"}}
+
+	return &decklist.Deck{
+		Main: []decklist.Entry{
+			{Quantity: 4, Name: bolt.Name, Card: bolt},
+			{Quantity: 4, Name: helix.Name, Card: helix},
+			{Quantity: 2, Name: golem.Name, Card: golem},
+			{Quantity: 2, Name: boros.Name, Card: boros},
+			{Quantity: 16, Name: mountain.Name, Card: mountain},
+			{Quantity: 4, Name: sacred.Name, Card: sacred},
+			{Quantity: 1, Name: "Unresolved"},
+		},
+		Sideboard: []decklist.Entry{
+			{Quantity: 4, Name: bolt.Name, Card: bolt},
+		},
+	}
+}
+
+func TestCompute(t *testing.T) {
+	t.Parallel()
+
+	stats := deckstats.Compute(deckstats.FromDeck(testDeck()))
+
+	if stats.Total != 32 || stats.Lands != 20 || stats.Nonlands != 12 {
+		t.Errorf("unexpected counts: %+v", stats)
+	}
+
+	if math.Abs(stats.LandRatio-20.0/32.0) > 1e-9 {
+		t.Errorf("unexpected land ratio %v", stats.LandRatio)
+	}
+
+	// (4*1 + 4*2 + 2*5 + 2*3) / 12
+	if math.Abs(stats.AverageManaValue-28.0/12.0) > 1e-9 {
+		t.Errorf("unexpected average mana value %v", stats.AverageManaValue)
+	}
+
+	expectedCurve := map[int]int{1: 4, 2: 4, 3: 2, 5: 2}
+	if len(stats.ManaCurve) != len(expectedCurve) {
+		t.Errorf("unexpected mana curve %v", stats.ManaCurve)
+	}
+
+	for cmc, count := range expectedCurve {
+		if stats.ManaCurve[cmc] != count {
+			t.Errorf("unexpected mana curve %v", stats.ManaCurve)
+		}
+	}
+
+	// Bolt 4, Helix 4 + 4, Reckoner 2 * 3 for each color.
+	if stats.ColorPips["R"] != 14 || stats.ColorPips["W"] != 10 {
+		t.Errorf("unexpected color pips %v", stats.ColorPips)
+	}
+
+	if stats.Types["Creature"] != 4 || stats.Types["Artifact"] != 2 || stats.Types["Instant"] != 8 || stats.Types["Land"] != 20 {
+		t.Errorf("unexpected types %v", stats.Types)
+	}
+
+	if stats.ManaSources["R"] != 20 || stats.ManaSources["W"] != 4 {
+		t.Errorf("unexpected mana sources %v", stats.ManaSources)
+	}
+}
+
+func TestCompute_DoubleFaced(t *testing.T) {
+	t.Parallel()
+
+	cathar := &gofall.Card{
+		Name:     "Brutal Cathar // Moonrage Brute",
+		TypeLine: "Creature — Human Soldier // Creature — Werewolf",
+		CMC:      3,
+		CardFaces: []gofall.CardFace{
+			{Name: "Brutal Cathar", ManaCost: "{2}{W}"},
+			{Name: "Moonrage Brute"},
+		},
+	}
+	call := &gofall.Card{
+		Name:     "Emeria's Call // Emeria, Shattered Skyclave",
+		TypeLine: "Sorcery // Land",
+		CMC:      7,
+		CardFaces: []gofall.CardFace{
+			{Name: "Emeria's Call", ManaCost: "{4}{W}{W}{W}"},
+			{Name: "Emeria, Shattered Skyclave"},
+		},
+	}
+
+	// Adventure cards have the costs of both halves in ManaCost.
+	attendants := &gofall.Card{
+		Name:     "Obyra's Attendants // Desperate Parry",
+		TypeLine: "Creature — Faerie Wizard // Instant — Adventure",
+		ManaCost: "{4}{U} // {1}{U}",
+		CMC:      5,
+	}
+
+	stats := deckstats.Compute(deckstats.FromDeck(&decklist.Deck{
+		Main: []decklist.Entry{
+			{Quantity: 4, Name: cathar.Name, Card: cathar},
+			{Quantity: 2, Name: call.Name, Card: call},
+			{Quantity: 3, Name: attendants.Name, Card: attendants},
+		},
+	}))
+
+	// Cathar 4 * 1, Emeria's Call 2 * 3, and Attendants 3 * 1.
+	if stats.ColorPips["W"] != 10 || stats.ColorPips["U"] != 3 {
+		t.Errorf("unexpected color pips %v", stats.ColorPips)
+	}
+}
+
+func TestHypergeometric(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{
+			name: "at least one land in opening hand",
+			got:  deckstats.HypergeometricAtLeast(60, 24, 7, 1),
+			want: 0.9783854727408821,
+		},
+		{
+			name: "two lands by turn three on the play",
+			got:  deckstats.ProbabilityByTurn(60, 24, 2, 3, true),
+			want: 0.9445049365437159,
+		},
+		{
+			name: "impossible",
+			got:  deckstats.HypergeometricAtLeast(60, 2, 7, 3),
+			want: 0,
+		},
+		{
+			name: "nothing wanted",
+			got:  deckstats.HypergeometricAtLeast(60, 0, 7, 0),
+			want: 1,
+		},
+	}
+
+	for _, v := range testCases {
+		if math.Abs(v.got-v.want) > 1e-9 {
+			t.Errorf("%s: got %v, want %v", v.name, v.got, v.want)
+		}
+	}
+
+	if seen := deckstats.CardsSeenByTurn(1, true); seen != 7 {
+		t.Errorf("expected 7 cards seen on turn 1 on the play, got %d", seen)
+	}
+}
@@ -0,0 +1,75 @@
+package deckstats
+
+import "math"
+
+// logChoose returns the natural logarithm of n choose k.
+func logChoose(n, k int) float64 {
+	nFact, _ := math.Lgamma(float64(n + 1))
+	kFact, _ := math.Lgamma(float64(k + 1))
+	nkFact, _ := math.Lgamma(float64(n - k + 1))
+
+	return nFact - kFact - nkFact
+}
+
+// HypergeometricExactly returns the probability of drawing exactly want
+// successes in draws cards from a deck of population cards containing
+// successes successes.
+func HypergeometricExactly(population, successes, draws, want int) float64 {
+	if want < 0 || want > successes || want > draws || draws-want > population-successes {
+		return 0
+	}
+
+	return math.Exp(
+		logChoose(successes, want) +
+			logChoose(population-successes, draws-want) -
+			logChoose(population, draws),
+	)
+}
+
+// HypergeometricAtLeast returns the probability of drawing at least want
+// successes in draws cards from a deck of population cards containing
+// successes successes.
+func HypergeometricAtLeast(population, successes, draws, want int) float64 {
+	if want <= 0 {
+		return 1
+	}
+
+	if draws > population {
+		draws = population
+	}
+
+	probability := 0.0
+	for k := want; k <= draws && k <= successes; k++ {
+		probability += HypergeometricExactly(population, successes, draws, k)
+	}
+
+	return math.Min(probability, 1)
+}
+
+// openingHandSize is the number of cards in an opening hand.
+const openingHandSize = 7
+
+// CardsSeenByTurn returns the number of cards a player has drawn by the given
+// turn, including their opening hand.  The player on the play skips their
+// first draw.
+func CardsSeenByTurn(turn int, onThePlay bool) int {
+	seen := openingHandSize + turn
+	if onThePlay {
+		seen--
+	}
+
+	return seen
+}
+
+// ProbabilityByTurn returns the probability of having drawn at least want
+// of the hits cards in a deck of deckSize cards by the given turn, e.g.
+// the chance of having 2 lands by turn 3.
+func ProbabilityByTurn(deckSize, hits, want, turn int, onThePlay bool) float64 {
+	return HypergeometricAtLeast(deckSize, hits, CardsSeenByTurn(turn, onThePlay), want)
+}
+
+// LandsByTurn returns the probability of having drawn at least want lands
+// by the given turn with the deck the stats were computed for.
+func (s Stats) LandsByTurn(want, turn int, onThePlay bool) float64 {
+	return ProbabilityByTurn(s.Total, s.Lands, want, turn, onThePlay)
+}
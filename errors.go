@@ -3,11 +3,17 @@ package gofall
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"strings"
 )
 
 // APIError is the response from the Scryfall API when an error occurs.
 // Most API methods in this package will return an APIError whenever possible.
+//
+// It can be matched against the sentinel errors below with errors.Is,
+// e.g. errors.Is(err, ErrNotFound), or unpacked with errors.As for the
+// details of the failure.
 type APIError struct {
 	// The HTTP status code of the response
 	Status int `json:"status"`
@@ -15,6 +21,7 @@ type APIError struct {
 	// A machine-friendly code for the error condition
 	Code string `json:"code"`
 
+	// A human-readable explanation of the error.
 	Details string `json:"details"`
 
 	// A computer-friendly string specifying more details about the error condition.
@@ -24,22 +31,120 @@ type APIError struct {
 	// This field can be empty.
 	Type string `json:"type"`
 
-	// A series of human-readable errors.
+	// A series of human-readable errors.  For bad requests these
+	// describe the problems with individual parameters.
 	Warnings []string `json:"warnings"`
+
+	// URL is the URL of the request that failed.
+	URL string `json:"-"`
 }
 
-func (a APIError) Error() string {
-	return fmt.Sprintf("API Error: %s: %s", a.Code, strings.Join(a.Warnings, " | "))
+func (a *APIError) Error() string {
+	msg := fmt.Sprintf("API Error: %d %s", a.Status, a.Code)
+
+	if a.Details != "" {
+		msg += ": " + a.Details
+	}
+
+	if len(a.Warnings) > 0 {
+		msg += ": " + strings.Join(a.Warnings, " | ")
+	}
+
+	if a.URL != "" {
+		msg += " (" + a.URL + ")"
+	}
+
+	return msg
 }
 
+var (
+	// ErrNotFound matches an APIError for a 404, including ambiguous
+	// fuzzy name matches.  Use ErrAmbiguous to tell those apart.
+	ErrNotFound = errors.New("not found")
+
+	// ErrAmbiguous matches an APIError for a fuzzy name lookup that
+	// matched more than one card.
+	ErrAmbiguous = errors.New("ambiguous")
+
+	// ErrBadRequest matches an APIError for a 400, e.g. an invalid search
+	// query.  The problems with the request are in APIError.Warnings.
+	ErrBadRequest = errors.New("bad request")
+
+	// ErrRateLimited matches an APIError for a 429, returned when
+	// Scryfall's rate limit has been exceeded.
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrServerError matches an APIError for any 5xx status.
+	ErrServerError = errors.New("server error")
+)
+
 // ErrNoBackFace is returned when a request asks for a card back,
 // but the card has no back face on Scryfall.
 var ErrNoBackFace = errors.New("no back face")
 
-func matchAPIError(err *APIError) error {
-	if err.Status == 422 {
-		return ErrNoBackFace
+// Is reports whether the error matches one of the sentinel errors,
+// so it can be used with errors.Is.
+func (a *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return a.Status == http.StatusNotFound
+	case ErrAmbiguous:
+		return a.Status == http.StatusNotFound && a.Type == "ambiguous"
+	case ErrBadRequest:
+		return a.Status == http.StatusBadRequest
+	case ErrRateLimited:
+		return a.Status == http.StatusTooManyRequests
+	case ErrServerError:
+		return a.Status >= http.StatusInternalServerError
+	case ErrNoBackFace:
+		return a.Status == http.StatusUnprocessableEntity && a.askedForBackFace()
+	default:
+		return false
 	}
+}
+
+// askedForBackFace reports whether the failed request asked for the back
+// face of a card, as Scryfall uses 422 for other errors too.
+func (a *APIError) askedForBackFace() bool {
+	if strings.Contains(strings.ToLower(a.Details), "back face") {
+		return true
+	}
+
+	parsed, err := url.Parse(a.URL)
+	if err != nil {
+		return false
+	}
+
+	return parsed.Query().Get("face") == "back"
+}
+
+// AmbiguousNameError is returned by CardClient.Named when a fuzzy name
+// matches more than one card.  It carries the names of the cards that
+// matched, so callers can offer a "did you mean" list.
+//
+// It unwraps to the APIError from Scryfall, so errors.Is(err, ErrAmbiguous)
+// also matches it.
+type AmbiguousNameError struct {
+	// Query is the fuzzy name that was looked up.
+	Query string
+
+	// Candidates are the names of cards matching Query.  It is empty if
+	// they could not be looked up.
+	Candidates []string
+
+	// Err is the error returned by Scryfall.
+	Err error
+}
+
+func (a *AmbiguousNameError) Error() string {
+	if len(a.Candidates) == 0 {
+		return fmt.Sprintf("ambiguous card name %q", a.Query)
+	}
+
+	return fmt.Sprintf("ambiguous card name %q, did you mean: %s", a.Query, strings.Join(a.Candidates, ", "))
+}
 
-	return nil
+// Unwrap returns the underlying error from Scryfall.
+func (a *AmbiguousNameError) Unwrap() error {
+	return a.Err
 }
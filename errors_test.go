@@ -0,0 +1,168 @@
+package gofall_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/SethCurry/gofall"
+)
+
+func Test_APIError_Taxonomy(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		status      int
+		body        string
+		matches     []error
+		doesntMatch []error
+		code        string
+	}{
+		{
+			name:        "not found",
+			status:      http.StatusNotFound,
+			body:        `{"object":"error","code":"not_found","status":404,"details":"No cards found matching “Black Lotsu”"}`,
+			matches:     []error{gofall.ErrNotFound},
+			doesntMatch: []error{gofall.ErrAmbiguous, gofall.ErrServerError},
+			code:        "not_found",
+		},
+		{
+			name:   "ambiguous",
+			status: http.StatusNotFound,
+			body: `{"object":"error","code":"not_found","status":404,"type":"ambiguous",` +
+				`"details":"Too many cards match ambiguous name “bolt”."}`,
+			matches: []error{gofall.ErrNotFound, gofall.ErrAmbiguous},
+			code:    "not_found",
+		},
+		{
+			name:   "bad request",
+			status: http.StatusBadRequest,
+			body: `{"object":"error","code":"bad_request","status":400,"details":"All of your terms were ignored.",` +
+				`"warnings":["Invalid expression “is:bogus” was ignored."]}`,
+			matches:     []error{gofall.ErrBadRequest},
+			doesntMatch: []error{gofall.ErrNotFound},
+			code:        "bad_request",
+		},
+		{
+			name:    "rate limited with HTML body",
+			status:  http.StatusTooManyRequests,
+			body:    `<html><body>Slow down</body></html>`,
+			matches: []error{gofall.ErrRateLimited},
+			code:    "too_many_requests",
+		},
+		{
+			name:        "server error with empty body",
+			status:      http.StatusServiceUnavailable,
+			matches:     []error{gofall.ErrServerError},
+			doesntMatch: []error{gofall.ErrRateLimited},
+			code:        "service_unavailable",
+		},
+		{
+			name:        "other unprocessable entity",
+			status:      http.StatusUnprocessableEntity,
+			body:        `{"object":"error","code":"unprocessable_entity","status":422,"details":"Too many identifiers."}`,
+			doesntMatch: []error{gofall.ErrNoBackFace},
+			code:        "unprocessable_entity",
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(v.status)
+				_, _ = io.WriteString(w, v.body)
+			}))
+
+			_, err := client.Card.Named(context.Background(), gofall.CardNamedRequest{Fuzzy: "bolt"})
+
+			var apiErr *gofall.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected an APIError, got %v", err)
+			}
+
+			if apiErr.Status != v.status || apiErr.Code != v.code {
+				t.Errorf("unexpected status %d and code %q", apiErr.Status, apiErr.Code)
+			}
+
+			if !strings.Contains(apiErr.URL, "/cards/named?fuzzy=bolt") {
+				t.Errorf("unexpected URL %q", apiErr.URL)
+			}
+
+			for _, target := range v.matches {
+				if !errors.Is(err, target) {
+					t.Errorf("expected error to match %v", target)
+				}
+			}
+
+			for _, target := range v.doesntMatch {
+				if errors.Is(err, target) {
+					t.Errorf("did not expect error to match %v", target)
+				}
+			}
+		})
+	}
+}
+
+func Test_AmbiguousNameError(t *testing.T) {
+	t.Parallel()
+
+	ambiguous := `{"object":"error","code":"not_found","status":404,"type":"ambiguous","details":"Too many cards match."}`
+
+	testCases := []struct {
+		name         string
+		autocomplete string
+		expected     []string
+	}{
+		{
+			name:         "autocomplete",
+			autocomplete: `{"object":"catalog","data":["Lightning Bolt","Bolt Bend"]}`,
+			expected:     []string{"Lightning Bolt", "Bolt Bend"},
+		},
+		{
+			name:         "search fallback",
+			autocomplete: `{"object":"catalog","data":[]}`,
+			expected:     []string{"Firebolt"},
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.name, func(t *testing.T) {
+			t.Parallel()
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/cards/named", func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = io.WriteString(w, ambiguous)
+			})
+			mux.HandleFunc("/cards/autocomplete", func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = io.WriteString(w, v.autocomplete)
+			})
+			mux.HandleFunc("/cards/search", func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = io.WriteString(w, `{"object":"list","has_more":false,"data":[{"object":"card","name":"Firebolt"}]}`)
+			})
+
+			client := newTestClient(t, mux)
+
+			_, err := client.Card.Named(context.Background(), gofall.CardNamedRequest{Fuzzy: "bolt"})
+
+			var ambiguousErr *gofall.AmbiguousNameError
+			if !errors.As(err, &ambiguousErr) {
+				t.Fatalf("expected an AmbiguousNameError, got %v", err)
+			}
+
+			if !errors.Is(err, gofall.ErrAmbiguous) {
+				t.Errorf("expected error to match ErrAmbiguous")
+			}
+
+			if ambiguousErr.Query != "bolt" || strings.Join(ambiguousErr.Candidates, "|") != strings.Join(v.expected, "|") {
+				t.Errorf("unexpected candidates for %q: %v", ambiguousErr.Query, ambiguousErr.Candidates)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package gofall_test
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+
+	"github.com/SethCurry/gofall"
+)
+
+// expvarHooks is an example Hooks adapter that publishes request
+// metrics with expvar.  Adapters for Prometheus or OpenTelemetry
+// follow the same pattern.
+type expvarHooks struct {
+	gofall.NoopHooks
+
+	requests *expvar.Int
+	bytes    *expvar.Int
+	retries  *expvar.Int
+	statuses *expvar.Map
+}
+
+func newExpvarHooks() *expvarHooks {
+	// Real adapters would use expvar.NewInt and expvar.NewMap
+	// to publish the metrics under a name.
+	return &expvarHooks{
+		requests: new(expvar.Int),
+		bytes:    new(expvar.Int),
+		retries:  new(expvar.Int),
+		statuses: new(expvar.Map).Init(),
+	}
+}
+
+func (e *expvarHooks) RequestEnd(_ context.Context, event gofall.RequestEndEvent) {
+	e.requests.Add(1)
+	e.bytes.Add(event.Bytes)
+	e.statuses.Add(strconv.Itoa(event.StatusCode), 1)
+}
+
+func (e *expvarHooks) Retry(context.Context, gofall.RetryEvent) {
+	e.retries.Add(1)
+}
+
+func Example_hooks() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"object":"card","name":"Lightning Bolt"}`)
+	}))
+	defer server.Close()
+
+	target, _ := url.Parse(server.URL)
+	hooks := newExpvarHooks()
+
+	client := gofall.NewClientWithOptions(gofall.ClientOptions{
+		HTTPClient: &http.Client{Transport: rewriteTransport{target: target}},
+		Hooks:      hooks,
+	})
+
+	card, err := client.Card.Named(context.Background(), gofall.CardNamedRequest{Exact: "Lightning Bolt"})
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(card.Name)
+	fmt.Println("requests:", hooks.requests)
+	fmt.Println("bytes:", hooks.bytes)
+	fmt.Println("statuses:", hooks.statuses)
+
+	// Output:
+	// Lightning Bolt
+	// requests: 1
+	// bytes: 41
+	// statuses: {"200": 1}
+}
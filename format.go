@@ -0,0 +1,157 @@
+package gofall
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownFormat is returned when unmarshaling a Format from a string
+// that is not one of the pre-defined formats.
+var ErrUnknownFormat = errors.New("unknown format")
+
+// Format is an enum representing a game format that Scryfall tracks
+// legality for, such as Standard or Commander.
+// See AllFormats() for all possible values.
+type Format string
+
+// String returns the format as a string.
+func (f Format) String() string {
+	return string(f)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (f *Format) UnmarshalText(txt []byte) error {
+	allFormats := AllFormats()
+	asFormat := Format(string(txt))
+
+	for _, format := range allFormats {
+		if format == asFormat {
+			*f = format
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrUnknownFormat, string(txt))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (f *Format) UnmarshalJSON(txt []byte) error {
+	var unmarshed string
+
+	err := json.Unmarshal(txt, &unmarshed)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal format: %w", err)
+	}
+
+	return f.UnmarshalText([]byte(unmarshed))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (f Format) MarshalText() ([]byte, error) {
+	return []byte(string(f)), nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (f Format) MarshalJSON() ([]byte, error) {
+	marshalled, err := json.Marshal(f.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal format: %w", err)
+	}
+
+	return marshalled, nil
+}
+
+const (
+	// FormatStandard is the rotating format of the most recent Standard-legal sets.
+	FormatStandard = Format("standard")
+
+	// FormatFuture is Standard including sets that have been announced but not yet released.
+	FormatFuture = Format("future")
+
+	// FormatHistoric is MTG Arena's non-rotating format.
+	FormatHistoric = Format("historic")
+
+	// FormatGladiator is a 100-card singleton MTG Arena format.
+	FormatGladiator = Format("gladiator")
+
+	// FormatPioneer is the non-rotating format of sets from Return to Ravnica onwards.
+	FormatPioneer = Format("pioneer")
+
+	// FormatExplorer is MTG Arena's approximation of Pioneer.
+	FormatExplorer = Format("explorer")
+
+	// FormatModern is the non-rotating format of sets from Eighth Edition onwards.
+	FormatModern = Format("modern")
+
+	// FormatLegacy is the eternal format with a ban list and no restricted list.
+	FormatLegacy = Format("legacy")
+
+	// FormatPauper is the format where only commons are allowed.
+	FormatPauper = Format("pauper")
+
+	// FormatVintage is the eternal format with a restricted list.
+	FormatVintage = Format("vintage")
+
+	// FormatPenny is Penny Dreadful, the MTGO format of cheap cards.
+	FormatPenny = Format("penny")
+
+	// FormatCommander is the 100-card singleton multiplayer format.
+	FormatCommander = Format("commander")
+
+	// FormatOathbreaker is the 60-card singleton format led by a planeswalker.
+	FormatOathbreaker = Format("oathbreaker")
+
+	// FormatBrawl is the singleton commander format played with Standard cards.
+	FormatBrawl = Format("brawl")
+
+	// FormatHistoricBrawl is Brawl played with Historic cards.
+	FormatHistoricBrawl = Format("historicbrawl")
+
+	// FormatAlchemy is MTG Arena's rotating format including digital-only cards.
+	FormatAlchemy = Format("alchemy")
+
+	// FormatPauperCommander is Commander played with commons and an uncommon commander.
+	FormatPauperCommander = Format("paupercommander")
+
+	// FormatDuel is Commander for two players, with its own ban list.
+	FormatDuel = Format("duel")
+
+	// FormatOldSchool is the format of cards printed in 1993 and 1994.
+	FormatOldSchool = Format("oldschool")
+
+	// FormatPreModern is the format of sets from Fourth Edition to Scourge.
+	FormatPreModern = Format("premodern")
+
+	// FormatPrEDH is Commander limited to cards printed before Commander existed.
+	FormatPrEDH = Format("predh")
+)
+
+// AllFormats returns a slice of all valid values of Format,
+// in the same order as the fields of CardLegality.
+func AllFormats() []Format {
+	return []Format{
+		FormatStandard,
+		FormatFuture,
+		FormatHistoric,
+		FormatGladiator,
+		FormatPioneer,
+		FormatExplorer,
+		FormatModern,
+		FormatLegacy,
+		FormatPauper,
+		FormatVintage,
+		FormatPenny,
+		FormatCommander,
+		FormatOathbreaker,
+		FormatBrawl,
+		FormatHistoricBrawl,
+		FormatAlchemy,
+		FormatPauperCommander,
+		FormatDuel,
+		FormatOldSchool,
+		FormatPreModern,
+		FormatPrEDH,
+	}
+}
@@ -0,0 +1,184 @@
+package gofall
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Hooks receives events about the requests a Client sends to Scryfall,
+// for collecting metrics or tracing.  Requests answered by a Cache or
+// shared through coalescing never reach the network and do not produce
+// events.
+//
+// Embed NoopHooks to only implement some of the methods.  Methods may be
+// called from multiple goroutines at once.
+type Hooks interface {
+	// RequestStart is called before a request is sent.
+	RequestStart(ctx context.Context, event RequestStartEvent)
+
+	// RequestEnd is called when a request finishes: after its response
+	// body has been closed, or as soon as it fails.
+	RequestEnd(ctx context.Context, event RequestEndEvent)
+
+	// LimiterWait is called when a request had to wait for the rate
+	// limiter before being sent.
+	LimiterWait(ctx context.Context, event LimiterWaitEvent)
+
+	// Retry is called each time a request is retried.
+	Retry(ctx context.Context, event RetryEvent)
+}
+
+// RequestStartEvent describes a request about to be sent.
+type RequestStartEvent struct {
+	Method string
+	URL    string
+}
+
+// RequestEndEvent describes a finished request.
+type RequestEndEvent struct {
+	Method string
+	URL    string
+
+	// StatusCode is the status of the response, or 0 if there was none.
+	StatusCode int
+
+	// Duration is the time from RequestStart until the request finished,
+	// including any time spent waiting for the rate limiter.
+	Duration time.Duration
+
+	// Bytes is the number of bytes of response body read.
+	Bytes int64
+
+	// Attempts is the number of times the request was tried.
+	Attempts int
+
+	// Err is the error the request failed with, if any.
+	Err error
+}
+
+// LimiterWaitEvent describes time a request spent waiting for the rate limiter.
+type LimiterWaitEvent struct {
+	URL  string
+	Wait time.Duration
+}
+
+// RetryEvent describes a request being retried.
+type RetryEvent struct {
+	URL string
+
+	// Attempt is the number of the attempt about to be made, starting at 2.
+	Attempt int
+
+	// Reason is why the request is being retried.
+	Reason string
+
+	// Backoff is how long the request waited before this attempt.
+	Backoff time.Duration
+}
+
+// NoopHooks is a Hooks that ignores every event.  It is used when
+// ClientOptions.Hooks is nil, and can be embedded by Hooks that
+// only care about some events.
+type NoopHooks struct{}
+
+// RequestStart implements the Hooks interface.
+func (NoopHooks) RequestStart(context.Context, RequestStartEvent) {}
+
+// RequestEnd implements the Hooks interface.
+func (NoopHooks) RequestEnd(context.Context, RequestEndEvent) {}
+
+// LimiterWait implements the Hooks interface.
+func (NoopHooks) LimiterWait(context.Context, LimiterWaitEvent) {}
+
+// Retry implements the Hooks interface.
+func (NoopHooks) Retry(context.Context, RetryEvent) {}
+
+// observer combines the Hooks and logger a transport reports to.
+type observer struct {
+	hooks  Hooks
+	logger *slog.Logger
+}
+
+func newObserver(hooks Hooks, logger *slog.Logger) observer {
+	if hooks == nil {
+		hooks = NoopHooks{}
+	}
+
+	return observer{hooks: hooks, logger: logger}
+}
+
+func (o observer) requestStart(ctx context.Context, event RequestStartEvent) {
+	o.hooks.RequestStart(ctx, event)
+}
+
+func (o observer) requestEnd(ctx context.Context, event RequestEndEvent) {
+	o.hooks.RequestEnd(ctx, event)
+
+	if o.logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", event.Method),
+		slog.String("url", event.URL),
+		slog.Int("status", event.StatusCode),
+		slog.Duration("duration", event.Duration),
+		slog.Int64("bytes", event.Bytes),
+		slog.Int("attempts", event.Attempts),
+	}
+
+	if event.Err != nil {
+		o.logger.LogAttrs(ctx, slog.LevelWarn, "scryfall request failed", append(attrs, slog.Any("error", event.Err))...)
+
+		return
+	}
+
+	o.logger.LogAttrs(ctx, slog.LevelDebug, "scryfall request", attrs...)
+}
+
+func (o observer) limiterWait(ctx context.Context, event LimiterWaitEvent) {
+	o.hooks.LimiterWait(ctx, event)
+
+	if o.logger != nil {
+		o.logger.LogAttrs(ctx, slog.LevelDebug, "waited for rate limiter",
+			slog.String("url", event.URL), slog.Duration("wait", event.Wait))
+	}
+}
+
+func (o observer) retry(ctx context.Context, event RetryEvent) {
+	o.hooks.Retry(ctx, event)
+
+	if o.logger != nil {
+		o.logger.LogAttrs(ctx, slog.LevelInfo, "retrying scryfall request",
+			slog.String("url", event.URL), slog.Int("attempt", event.Attempt),
+			slog.String("reason", event.Reason), slog.Duration("backoff", event.Backoff))
+	}
+}
+
+// observedBody counts the bytes read from a response body and
+// reports the end of the request when it is closed.
+type observedBody struct {
+	io.ReadCloser
+
+	bytes int64
+	once  sync.Once
+	end   func(bytes int64)
+}
+
+func (o *observedBody) Read(p []byte) (int, error) {
+	n, err := o.ReadCloser.Read(p)
+	o.bytes += int64(n)
+
+	return n, err
+}
+
+func (o *observedBody) Close() error {
+	err := o.ReadCloser.Close()
+
+	o.once.Do(func() { o.end(o.bytes) })
+
+	return err
+}
@@ -0,0 +1,295 @@
+package gofall
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrImageDownload is returned when the image CDN responds with
+// anything other than a 200.
+var ErrImageDownload = errors.New("failed to download image")
+
+// ErrInvalidFace is returned when an image is requested for a negative face.
+var ErrInvalidFace = errors.New("invalid face")
+
+// ImageFetcherOptions configures an ImageFetcher.
+// The zero value is valid and uses the defaults described on each field.
+type ImageFetcherOptions struct {
+	// CacheDir is the directory downloaded images are stored in.
+	// Defaults to a "gofall/images" directory in os.UserCacheDir().
+	CacheDir string
+
+	// Client is the HTTP client to download images with.  Its transport
+	// is wrapped with a rate limiter separate from the API's, as
+	// cards.scryfall.io has its own limits.  Defaults to a client
+	// with a 30 second timeout.
+	Client *http.Client
+
+	// MaxRequests is the number of image downloads allowed per Window.
+	// Defaults to 10.
+	MaxRequests int
+
+	// Window is the period MaxRequests applies to.  Defaults to one second.
+	Window time.Duration
+
+	// Clock is used by the rate limiter.  Defaults to SystemClock.
+	Clock Clock
+}
+
+// NewImageFetcher creates an ImageFetcher, creating the cache directory
+// if it does not exist.
+func NewImageFetcher(opts ImageFetcherOptions) (*ImageFetcher, error) {
+	defaultMaxRetries := 5
+	defaultMaxRequests := 10
+	defaultWindow := time.Second
+	defaultTimeoutSeconds := 30
+
+	if opts.CacheDir == "" {
+		userCache, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to find user cache directory: %w", err)
+		}
+
+		opts.CacheDir = filepath.Join(userCache, "gofall", "images")
+	}
+
+	if err := os.MkdirAll(opts.CacheDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create image cache directory: %w", err)
+	}
+
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: time.Second * time.Duration(defaultTimeoutSeconds)}
+	}
+
+	if opts.MaxRequests <= 0 {
+		opts.MaxRequests = defaultMaxRequests
+	}
+
+	if opts.Window <= 0 {
+		opts.Window = defaultWindow
+	}
+
+	if opts.Clock == nil {
+		opts.Clock = SystemClock{}
+	}
+
+	transport := http.DefaultTransport
+	if opts.Client.Transport != nil {
+		transport = opts.Client.Transport
+	}
+
+	return &ImageFetcher{
+		client: &http.Client{
+			Transport: &roundTripper{
+				maxRetries: defaultMaxRetries,
+				limiter:    newRateLimiter(opts.Window, opts.MaxRequests, opts.Clock),
+				inner:      transport,
+				clock:      opts.Clock,
+			},
+			CheckRedirect: opts.Client.CheckRedirect,
+			Jar:           opts.Client.Jar,
+			Timeout:       opts.Client.Timeout,
+		},
+		cacheDir: opts.CacheDir,
+		inflight: map[string]*imageCall{},
+	}, nil
+}
+
+// ImageFetcher downloads card images and caches them on disk.
+//
+// Images are keyed by the card's ID, the face, the image type and the
+// version Scryfall appends to image URIs, so a new scan of a card is
+// downloaded again while unchanged images are only ever downloaded once.
+// Concurrent requests for the same image share a single download.
+//
+// It is safe to use from multiple goroutines.
+type ImageFetcher struct {
+	client   *http.Client
+	cacheDir string
+
+	lock     sync.Mutex
+	inflight map[string]*imageCall
+}
+
+// imageCall is a download in progress that other callers can wait on.
+type imageCall struct {
+	done chan struct{}
+	path string
+	err  error
+}
+
+// imageURI returns the URI of the image for a face of the card.
+// Face 0 is the front; single-faced cards only have face 0.
+func imageURI(card *Card, imageType ImageType, face int) (string, error) {
+	if face < 0 {
+		return "", fmt.Errorf("%w: %d", ErrInvalidFace, face)
+	}
+
+	uris := &card.ImageURIs
+
+	switch {
+	case len(card.CardFaces) > face && card.CardFaces[face].ImageURIs.Get(imageType) != "":
+		// Double-faced cards have images per face.
+		uris = &card.CardFaces[face].ImageURIs
+	case face > 0:
+		return "", ErrNoBackFace
+	}
+
+	uri := uris.Get(imageType)
+	if uri == "" {
+		return "", fmt.Errorf("%w: %s has no %s image", ErrNoImageURIs, card.Name, imageType)
+	}
+
+	return uri, nil
+}
+
+// cachePath returns where the image is stored in the cache.
+func (f *ImageFetcher) cachePath(card *Card, imageType ImageType, face int, uri string) (string, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse image URI %q: %w", uri, err)
+	}
+
+	// Scryfall appends the image's version as a bare query string,
+	// e.g. "?1562404626".
+	sum := sha256.Sum256([]byte(card.ID + "\x00" + strconv.Itoa(face) + "\x00" + string(imageType) + "\x00" + parsed.RawQuery))
+	key := hex.EncodeToString(sum[:])
+
+	return filepath.Join(f.cacheDir, key[:2], key+path.Ext(parsed.Path)), nil
+}
+
+// Path returns the path of a cached copy of the image for a face of the
+// card, downloading it first if it is not already cached.  Face 0 is
+// the front face.
+//
+// If another goroutine is already downloading the same image, Path
+// waits for that download instead of starting another.  Cancelling ctx
+// only stops this caller waiting; the download carries on for the others.
+func (f *ImageFetcher) Path(ctx context.Context, card *Card, imageType ImageType, face int) (string, error) {
+	uri, err := imageURI(card, imageType, face)
+	if err != nil {
+		return "", err
+	}
+
+	cachePath, err := f.cachePath(card, imageType, face, uri)
+	if err != nil {
+		return "", err
+	}
+
+	f.lock.Lock()
+
+	call, ok := f.inflight[cachePath]
+	if !ok {
+		// Checked while holding the lock, as a download finishing
+		// moves the file into place before leaving inflight.
+		if _, err := os.Stat(cachePath); err == nil {
+			f.lock.Unlock()
+
+			return cachePath, nil
+		}
+
+		call = &imageCall{done: make(chan struct{})}
+		f.inflight[cachePath] = call
+
+		// The download is shared, so it must not be cancelled by the
+		// caller that happened to start it.  It finishes and is cached
+		// even if every caller gives up.
+		go f.finish(context.WithoutCancel(ctx), call, uri, cachePath)
+	}
+
+	f.lock.Unlock()
+
+	select {
+	case <-call.done:
+		return call.path, call.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// finish downloads the image for call and wakes up its waiters.
+func (f *ImageFetcher) finish(ctx context.Context, call *imageCall, uri, cachePath string) {
+	call.err = f.download(ctx, uri, cachePath)
+	if call.err == nil {
+		call.path = cachePath
+	}
+
+	f.lock.Lock()
+	delete(f.inflight, cachePath)
+	f.lock.Unlock()
+
+	close(call.done)
+}
+
+// Open returns the image for a face of the card, downloading it first
+// if it is not already cached.  The caller must close the returned reader.
+func (f *ImageFetcher) Open(ctx context.Context, card *Card, imageType ImageType, face int) (io.ReadCloser, error) {
+	cachePath, err := f.Path(ctx, card, imageType, face)
+	if err != nil {
+		return nil, err
+	}
+
+	fd, err := os.Open(cachePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cached image: %w", err)
+	}
+
+	return fd, nil
+}
+
+// download saves the image at uri to cachePath.  The image is written to
+// a temporary file first so a partial download is never left in the cache.
+func (f *ImageFetcher) download(ctx context.Context, uri, cachePath string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s returned %d", ErrImageDownload, uri, resp.StatusCode)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0o755); err != nil {
+		return fmt.Errorf("failed to create image cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(cachePath), "download-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary image file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, resp.Body); err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("failed to write image: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write image: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), cachePath); err != nil {
+		return fmt.Errorf("failed to move image into cache: %w", err)
+	}
+
+	return nil
+}
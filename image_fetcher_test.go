@@ -0,0 +1,214 @@
+package gofall_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/SethCurry/gofall"
+)
+
+func newImageServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var hits atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+
+		if r.URL.Path == "/missing.jpg" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "image/jpeg")
+		_, _ = io.WriteString(w, r.URL.Path+"?"+r.URL.RawQuery)
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server, &hits
+}
+
+func Test_ImageFetcher(t *testing.T) {
+	t.Parallel()
+
+	server, hits := newImageServer(t)
+
+	fetcher, err := gofall.NewImageFetcher(gofall.ImageFetcherOptions{CacheDir: t.TempDir(), MaxRequests: 100})
+	if err != nil {
+		t.Fatalf("failed to create image fetcher: %v", err)
+	}
+
+	card := &gofall.Card{
+		ID:        "card-id",
+		Name:      "Test Card",
+		ImageURIs: gofall.ImageURIs{Large: server.URL + "/large.jpg?1"},
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := fetcher.Path(context.Background(), card, gofall.ImageTypeLarge, 0); err != nil {
+				t.Errorf("failed to fetch image: %v", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if got := hits.Load(); got != 1 {
+		t.Errorf("expected concurrent fetches to share one download, got %d", got)
+	}
+
+	reader, err := fetcher.Open(context.Background(), card, gofall.ImageTypeLarge, 0)
+	if err != nil {
+		t.Fatalf("failed to open cached image: %v", err)
+	}
+
+	body, _ := io.ReadAll(reader)
+	reader.Close()
+
+	if string(body) != "/large.jpg?1" {
+		t.Errorf("unexpected image contents: %q", body)
+	}
+
+	if got := hits.Load(); got != 1 {
+		t.Errorf("expected a cached image to not be downloaded again, got %d downloads", got)
+	}
+
+	card.ImageURIs.Large = server.URL + "/large.jpg?2"
+
+	if _, err := fetcher.Path(context.Background(), card, gofall.ImageTypeLarge, 0); err != nil {
+		t.Fatalf("failed to fetch new version: %v", err)
+	}
+
+	if got := hits.Load(); got != 2 {
+		t.Errorf("expected a new version to be downloaded, got %d downloads", got)
+	}
+}
+
+func Test_ImageFetcher_Faces(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newImageServer(t)
+
+	fetcher, err := gofall.NewImageFetcher(gofall.ImageFetcherOptions{CacheDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to create image fetcher: %v", err)
+	}
+
+	card := &gofall.Card{
+		ID: "dfc-id",
+		CardFaces: []gofall.CardFace{
+			{Name: "Front", ImageURIs: gofall.ImageURIs{Small: server.URL + "/front.jpg?1"}},
+			{Name: "Back", ImageURIs: gofall.ImageURIs{Small: server.URL + "/back.jpg?1"}},
+		},
+	}
+
+	for face, expected := range []string{"/front.jpg?1", "/back.jpg?1"} {
+		reader, err := fetcher.Open(context.Background(), card, gofall.ImageTypeSmall, face)
+		if err != nil {
+			t.Fatalf("failed to open face %d: %v", face, err)
+		}
+
+		body, _ := io.ReadAll(reader)
+		reader.Close()
+
+		if string(body) != expected {
+			t.Errorf("unexpected image for face %d: %q", face, body)
+		}
+	}
+
+	if _, err := fetcher.Path(context.Background(), card, gofall.ImageTypeLarge, 0); !errors.Is(err, gofall.ErrNoImageURIs) {
+		t.Errorf("expected ErrNoImageURIs, got %v", err)
+	}
+
+	single := &gofall.Card{ID: "single", ImageURIs: gofall.ImageURIs{Small: server.URL + "/single.jpg?1"}}
+
+	if _, err := fetcher.Path(context.Background(), single, gofall.ImageTypeSmall, 1); !errors.Is(err, gofall.ErrNoBackFace) {
+		t.Errorf("expected ErrNoBackFace, got %v", err)
+	}
+
+	if _, err := fetcher.Path(context.Background(), card, gofall.ImageTypeSmall, -1); !errors.Is(err, gofall.ErrInvalidFace) {
+		t.Errorf("expected ErrInvalidFace, got %v", err)
+	}
+
+	missing := &gofall.Card{ID: "missing", ImageURIs: gofall.ImageURIs{Small: server.URL + "/missing.jpg?1"}}
+
+	if _, err := fetcher.Path(context.Background(), missing, gofall.ImageTypeSmall, 0); !errors.Is(err, gofall.ErrImageDownload) {
+		t.Errorf("expected ErrImageDownload, got %v", err)
+	}
+}
+
+func Test_ImageFetcher_CancelFirst(t *testing.T) {
+	t.Parallel()
+
+	var hits atomic.Int32
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) == 1 {
+			close(started)
+		}
+
+		<-release
+
+		_, _ = io.WriteString(w, r.URL.Path)
+	}))
+	t.Cleanup(server.Close)
+
+	fetcher, err := gofall.NewImageFetcher(gofall.ImageFetcherOptions{CacheDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to create image fetcher: %v", err)
+	}
+
+	card := &gofall.Card{ID: "card-id", ImageURIs: gofall.ImageURIs{Small: server.URL + "/small.jpg?1"}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+
+	go func() {
+		_, err := fetcher.Path(ctx, card, gofall.ImageTypeSmall, 0)
+		firstErr <- err
+	}()
+
+	<-started
+
+	secondErr := make(chan error, 1)
+
+	go func() {
+		_, err := fetcher.Path(context.Background(), card, gofall.ImageTypeSmall, 0)
+		secondErr <- err
+	}()
+
+	// The first caller giving up must not cancel the shared download.
+	cancel()
+
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	close(release)
+
+	if err := <-secondErr; err != nil {
+		t.Errorf("expected the second caller to get the image, got %v", err)
+	}
+
+	if got := hits.Load(); got != 1 {
+		t.Errorf("expected one download, got %d", got)
+	}
+}
@@ -73,3 +73,24 @@ func (i *ImageURIs) HighestQuality() (string, error) {
 
 	return "", ErrNoImageURIs
 }
+
+// Get returns the URI for the image type, or an empty string
+// if there is none.
+func (i *ImageURIs) Get(imageType ImageType) string {
+	switch imageType {
+	case ImageTypeSmall:
+		return i.Small
+	case ImageTypeNormal:
+		return i.Normal
+	case ImageTypeLarge:
+		return i.Large
+	case ImageTypePng:
+		return i.PNG
+	case ImageTypeArtCrop:
+		return i.ArtCrop
+	case ImageTypeBorderCrop:
+		return i.BorderCrop
+	default:
+		return ""
+	}
+}
@@ -51,7 +51,12 @@ func (o Object) MarshalText() ([]byte, error) {
 
 // MarshalJSON implements the json.Marshaler interface.
 func (o Object) MarshalJSON() ([]byte, error) {
-	return o.MarshalText()
+	marshalled, err := json.Marshal(o.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal object: %w", err)
+	}
+
+	return marshalled, nil
 }
 
 const (
@@ -72,6 +77,9 @@ const (
 
 	// ObjectCatalog identifies an API response that contains a catalog.
 	ObjectCatalog = Object("catalog")
+
+	// ObjectCardFace identifies a single face of a multi-faced card.
+	ObjectCardFace = Object("card_face")
 )
 
 // AllObjects returns a list of all valid values of Object.
@@ -82,5 +90,7 @@ func AllObjects() []Object {
 		ObjectRuling,
 		ObjectBulkData,
 		ObjectList,
+		ObjectCatalog,
+		ObjectCardFace,
 	}
 }
@@ -0,0 +1,50 @@
+package gofall_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/SethCurry/gofall"
+)
+
+func Test_Object_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	for _, object := range gofall.AllObjects() {
+		marshalled, err := json.Marshal(object)
+		if err != nil {
+			t.Fatalf("failed to marshal %s: %v", object, err)
+		}
+
+		var got gofall.Object
+
+		if err := json.Unmarshal(marshalled, &got); err != nil {
+			t.Fatalf("failed to unmarshal %s: %v", marshalled, err)
+		}
+
+		if got != object {
+			t.Errorf("unexpected object: got %v, want %v", got, object)
+		}
+	}
+}
+
+func Test_Source_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	for _, source := range []gofall.Source{gofall.SourceWOTC, gofall.SourceScryfall} {
+		marshalled, err := json.Marshal(source)
+		if err != nil {
+			t.Fatalf("failed to marshal %s: %v", source, err)
+		}
+
+		var got gofall.Source
+
+		if err := json.Unmarshal(marshalled, &got); err != nil {
+			t.Fatalf("failed to unmarshal %s: %v", marshalled, err)
+		}
+
+		if got != source {
+			t.Errorf("unexpected source: got %v, want %v", got, source)
+		}
+	}
+}
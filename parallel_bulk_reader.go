@@ -0,0 +1,308 @@
+package gofall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+)
+
+// ParallelBulkReaderOptions configures a ParallelBulkReader.
+// The zero value is valid and uses the defaults described on each field.
+type ParallelBulkReaderOptions struct {
+	// Workers is the number of goroutines unmarshaling elements.
+	// Defaults to runtime.NumCPU() if zero or negative.
+	Workers int
+
+	// PreserveOrder makes Next return elements in the same order they
+	// appear in the source.  When false, elements are returned as soon
+	// as they have been unmarshaled, which is faster but unordered.
+	PreserveOrder bool
+
+	// MaxPending is the maximum number of elements held in memory at once,
+	// counting elements waiting to be unmarshaled and decoded elements waiting
+	// to be returned by Next.  Defaults to 4 * Workers if zero or negative.
+	MaxPending int
+
+	// BulkReaderOptions controls skipping of invalid elements the same
+	// way it does for BulkReader.  OnError is always called from the
+	// goroutine calling Next.
+	BulkReaderOptions
+}
+
+func (p ParallelBulkReaderOptions) withDefaults() ParallelBulkReaderOptions {
+	if p.Workers <= 0 {
+		p.Workers = runtime.NumCPU()
+	}
+
+	if p.MaxPending <= 0 {
+		p.MaxPending = 4 * p.Workers
+	}
+
+	return p
+}
+
+// bulkElement is a single raw element of a bulk JSON array.
+type bulkElement struct {
+	index int
+	raw   json.RawMessage
+}
+
+// bulkResult is the outcome of unmarshaling a single bulkElement.
+type bulkResult[T any] struct {
+	index int
+	value *T
+	err   error
+
+	// fatal is set when the error came from splitting the array,
+	// after which no more elements can be read.
+	fatal bool
+}
+
+// NewParallelBulkReader creates a ParallelBulkReader[T] from a given io.Reader.
+// One goroutine splits the top-level JSON array into raw elements and
+// opts.Workers goroutines unmarshal them into T.
+//
+// Cancelling ctx stops all of the reader's goroutines, and Next will return
+// ctx.Err().  The ParallelBulkReader will not close the underlying reader.
+func NewParallelBulkReader[T any](
+	ctx context.Context,
+	src io.Reader,
+	opts ParallelBulkReaderOptions,
+) (*ParallelBulkReader[T], error) {
+	decoder, err := openBulkArray(src)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = opts.withDefaults()
+	ctx, cancel := context.WithCancel(ctx)
+
+	reader := &ParallelBulkReader[T]{
+		ctx:           ctx,
+		cancel:        cancel,
+		preserveOrder: opts.PreserveOrder,
+		opts:          opts.BulkReaderOptions,
+		results:       make(chan bulkResult[T], opts.MaxPending),
+		tokens:        make(chan struct{}, opts.MaxPending),
+		pending:       map[int]bulkResult[T]{},
+	}
+
+	jobs := make(chan bulkElement, opts.Workers)
+
+	var wg sync.WaitGroup
+
+	wg.Add(1 + opts.Workers)
+
+	go func() {
+		defer wg.Done()
+		reader.split(decoder, jobs)
+	}()
+
+	for i := 0; i < opts.Workers; i++ {
+		go func() {
+			defer wg.Done()
+			reader.work(jobs)
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(reader.results)
+	}()
+
+	return reader, nil
+}
+
+// ParallelBulkReader[T] reads lists of data encoded as JSON, unmarshaling
+// elements concurrently.  It is an alternative to BulkReader[T] for large
+// sources such as the all_cards export.
+//
+// Next is not safe to call from multiple goroutines.
+type ParallelBulkReader[T any] struct {
+	ctx           context.Context //nolint:containedctx
+	cancel        context.CancelFunc
+	preserveOrder bool
+	opts          BulkReaderOptions
+	skipped       []*BulkElementError
+
+	// results receives decoded elements from the workers.
+	results chan bulkResult[T]
+
+	// tokens bounds the number of elements in flight.  The splitter
+	// acquires a token per element and Next releases it.
+	tokens chan struct{}
+
+	// pending holds out-of-order results when preserveOrder is set,
+	// and a held back split error otherwise.
+	pending   map[int]bulkResult[T]
+	nextIndex int
+	err       error
+}
+
+// split reads raw elements from the decoder and sends them to the workers.
+func (p *ParallelBulkReader[T]) split(decoder *json.Decoder, jobs chan<- bulkElement) {
+	defer close(jobs)
+
+	for index := 0; decoder.More(); index++ {
+		select {
+		case p.tokens <- struct{}{}:
+		case <-p.ctx.Done():
+			return
+		}
+
+		var raw json.RawMessage
+
+		if err := decoder.Decode(&raw); err != nil {
+			select {
+			case p.results <- bulkResult[T]{
+				index: index,
+				err:   fmt.Errorf("failed to split JSON for bulk: %w", err),
+				fatal: true,
+			}:
+			case <-p.ctx.Done():
+			}
+
+			return
+		}
+
+		select {
+		case jobs <- bulkElement{index: index, raw: raw}:
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+// work unmarshals raw elements until jobs is closed or the context is cancelled.
+func (p *ParallelBulkReader[T]) work(jobs <-chan bulkElement) {
+	for elem := range jobs {
+		result := bulkResult[T]{index: elem.index}
+
+		var value T
+
+		if err := json.Unmarshal(elem.raw, &value); err != nil {
+			result.err = &BulkElementError{Index: elem.index, Raw: elem.raw, Err: err}
+		} else {
+			result.value = &value
+		}
+
+		select {
+		case p.results <- result:
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+// Next returns the next item in the reader.  It returns io.EOF if there are no more items.
+//
+// It returns a non-EOF error if the next item could not be parsed.  If the
+// element was valid JSON but could not be unmarshaled into T, the error is
+// a *BulkElementError and reading can continue by calling Next again, or the
+// element is skipped if SkipInvalid is set.  If the JSON stream itself is
+// malformed, the same error is returned by every subsequent call.
+func (p *ParallelBulkReader[T]) Next() (*T, error) {
+	for {
+		value, err := p.next()
+
+		var elemErr *BulkElementError
+		if p.opts.SkipInvalid && errors.As(err, &elemErr) {
+			p.opts.report(elemErr, &p.skipped)
+
+			continue
+		}
+
+		return value, err
+	}
+}
+
+// Skipped returns the elements skipped so far because of SkipInvalid.
+// It is always empty if OnError is set, as skipped elements are passed
+// to the callback instead.
+func (p *ParallelBulkReader[T]) Skipped() []*BulkElementError {
+	return p.skipped
+}
+
+func (p *ParallelBulkReader[T]) next() (*T, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	if err := p.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for {
+		if p.preserveOrder {
+			if result, ok := p.pending[p.nextIndex]; ok {
+				delete(p.pending, p.nextIndex)
+				p.nextIndex++
+
+				return p.deliver(result)
+			}
+		}
+
+		select {
+		case result, ok := <-p.results:
+			if !ok {
+				return p.finish()
+			}
+
+			if !p.preserveOrder {
+				// Hold back a split error until the workers have
+				// returned every element read before it.
+				if result.fatal {
+					p.pending[result.index] = result
+
+					continue
+				}
+
+				return p.deliver(result)
+			}
+
+			p.pending[result.index] = result
+		case <-p.ctx.Done():
+			return nil, p.ctx.Err()
+		}
+	}
+}
+
+// finish is called once every goroutine has exited.  It returns a held
+// back split error if there is one, or io.EOF.
+func (p *ParallelBulkReader[T]) finish() (*T, error) {
+	if err := p.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for index, result := range p.pending {
+		delete(p.pending, index)
+
+		return p.deliver(result)
+	}
+
+	return nil, io.EOF
+}
+
+// deliver releases the element's token and unpacks the result.
+func (p *ParallelBulkReader[T]) deliver(result bulkResult[T]) (*T, error) {
+	<-p.tokens
+
+	if result.fatal {
+		p.err = result.err
+		p.cancel()
+	}
+
+	return result.value, result.err
+}
+
+// Close stops the reader's goroutines.  It does not close the underlying reader.
+// It is safe to call Close more than once.
+func (p *ParallelBulkReader[T]) Close() error {
+	p.cancel()
+
+	return nil
+}
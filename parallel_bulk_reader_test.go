@@ -0,0 +1,246 @@
+package gofall_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/SethCurry/gofall"
+)
+
+func readAllCards(t *testing.T) []gofall.Card {
+	t.Helper()
+
+	testFd, err := os.Open("test/cards.json")
+	if err != nil {
+		t.Fatalf("failed to open test cards file: %v", err)
+	}
+
+	defer testFd.Close()
+
+	bulkReader, err := gofall.NewBulkReader[gofall.Card](testFd)
+	if err != nil {
+		t.Fatalf("failed to create new bulk card reader: %v", err)
+	}
+
+	var cards []gofall.Card
+
+	for {
+		card, err := bulkReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf("got unexpected error while reading next card: %v", err)
+		}
+
+		cards = append(cards, *card)
+	}
+
+	return cards
+}
+
+func TestParallelBulkReader_Cards(t *testing.T) {
+	t.Parallel()
+
+	expected := readAllCards(t)
+
+	testCases := []struct {
+		name string
+		opts gofall.ParallelBulkReaderOptions
+	}{
+		{
+			name: "defaults",
+			opts: gofall.ParallelBulkReaderOptions{},
+		},
+		{
+			name: "ordered",
+			opts: gofall.ParallelBulkReaderOptions{Workers: 4, PreserveOrder: true, MaxPending: 2},
+		},
+		{
+			name: "single worker",
+			opts: gofall.ParallelBulkReaderOptions{Workers: 1, PreserveOrder: true, MaxPending: 1},
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.name, func(t *testing.T) {
+			t.Parallel()
+
+			testFd, err := os.Open("test/cards.json")
+			if err != nil {
+				t.Fatalf("failed to open test cards file: %v", err)
+			}
+
+			defer testFd.Close()
+
+			reader, err := gofall.NewParallelBulkReader[gofall.Card](context.Background(), testFd, v.opts)
+			if err != nil {
+				t.Fatalf("failed to create parallel bulk reader: %v", err)
+			}
+
+			defer reader.Close()
+
+			seen := map[string]int{}
+
+			for i := 0; ; i++ {
+				card, err := reader.Next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+
+				if err != nil {
+					t.Fatalf("got unexpected error while reading next card: %v", err)
+				}
+
+				if v.opts.PreserveOrder && card.ID != expected[i].ID {
+					t.Errorf("card %d has ID %q, expected %q", i, card.ID, expected[i].ID)
+				}
+
+				seen[card.ID]++
+			}
+
+			if len(seen) != len(expected) {
+				t.Errorf("expected %d cards, got %d", len(expected), len(seen))
+			}
+
+			for _, card := range expected {
+				if seen[card.ID] != 1 {
+					t.Errorf("expected card %q exactly once, got %d", card.ID, seen[card.ID])
+				}
+			}
+		})
+	}
+}
+
+func TestParallelBulkReader_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("element error", func(t *testing.T) {
+		t.Parallel()
+
+		src := bytes.NewBufferString(`[{"oracle_id":"a"},{"legalities":{"standard":"bogus"}},{"oracle_id":"c"}]`)
+
+		reader, err := gofall.NewParallelBulkReader[gofall.Card](
+			context.Background(), src, gofall.ParallelBulkReaderOptions{PreserveOrder: true},
+		)
+		if err != nil {
+			t.Fatalf("failed to create parallel bulk reader: %v", err)
+		}
+
+		defer reader.Close()
+
+		if card, err := reader.Next(); err != nil || card.OracleID != "a" {
+			t.Fatalf("expected first card, got %v, %v", card, err)
+		}
+
+		_, err = reader.Next()
+		if !errors.Is(err, gofall.ErrUnknownLegality) {
+			t.Fatalf("expected ErrUnknownLegality, got %v", err)
+		}
+
+		var elemErr *gofall.BulkElementError
+		if !errors.As(err, &elemErr) || elemErr.Index != 1 {
+			t.Errorf("expected a BulkElementError for index 1, got %v", err)
+		}
+
+		if card, err := reader.Next(); err != nil || card.OracleID != "c" {
+			t.Fatalf("expected third card, got %v, %v", card, err)
+		}
+
+		if _, err := reader.Next(); !errors.Is(err, io.EOF) {
+			t.Errorf("expected io.EOF, got %v", err)
+		}
+	})
+
+	t.Run("skip invalid", func(t *testing.T) {
+		t.Parallel()
+
+		src := bytes.NewBufferString(`[{"oracle_id":"a"},{"legalities":{"standard":"bogus"}},{"oracle_id":"c"}]`)
+
+		reader, err := gofall.NewParallelBulkReader[gofall.Card](
+			context.Background(), src, gofall.ParallelBulkReaderOptions{
+				BulkReaderOptions: gofall.BulkReaderOptions{SkipInvalid: true},
+			},
+		)
+		if err != nil {
+			t.Fatalf("failed to create parallel bulk reader: %v", err)
+		}
+
+		defer reader.Close()
+
+		numCards := 0
+
+		for {
+			_, err := reader.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			if err != nil {
+				t.Fatalf("got unexpected error while reading next card: %v", err)
+			}
+
+			numCards++
+		}
+
+		if numCards != 2 {
+			t.Errorf("expected 2 cards, got %d", numCards)
+		}
+
+		if skipped := reader.Skipped(); len(skipped) != 1 || skipped[0].Index != 1 {
+			t.Errorf("unexpected skipped elements: %v", skipped)
+		}
+	})
+
+	t.Run("malformed stream", func(t *testing.T) {
+		t.Parallel()
+
+		src := bytes.NewBufferString(`[{"oracle_id":"a"},{"oracle_id":`)
+
+		reader, err := gofall.NewParallelBulkReader[gofall.Card](
+			context.Background(), src, gofall.ParallelBulkReaderOptions{},
+		)
+		if err != nil {
+			t.Fatalf("failed to create parallel bulk reader: %v", err)
+		}
+
+		defer reader.Close()
+
+		if card, err := reader.Next(); err != nil || card.OracleID != "a" {
+			t.Fatalf("expected first card, got %v, %v", card, err)
+		}
+
+		_, err = reader.Next()
+		if err == nil || errors.Is(err, io.EOF) {
+			t.Fatalf("expected a parse error, got %v", err)
+		}
+
+		if _, again := reader.Next(); !errors.Is(again, err) {
+			t.Errorf("expected the same error again, got %v", again)
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		reader, err := gofall.NewParallelBulkReader[gofall.Card](
+			ctx, bytes.NewBufferString(`[{"oracle_id":"a"}]`), gofall.ParallelBulkReaderOptions{},
+		)
+		if err != nil {
+			t.Fatalf("failed to create parallel bulk reader: %v", err)
+		}
+
+		cancel()
+
+		if _, err := reader.Next(); !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,236 @@
+package gofall
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidPrice is returned when parsing a Price from a string that
+// is not a decimal number with at most four decimal places.
+var ErrInvalidPrice = errors.New("invalid price")
+
+// priceScale is the number of Price units in one whole unit of currency.
+const priceScale = 10000
+
+// priceDecimals is the number of decimal places a Price can hold.
+const priceDecimals = 4
+
+// Price is an exact, nullable decimal amount of money, such as the
+// prices Scryfall reports for a card.  It is stored as a fixed-point
+// integer with four decimal places, so sums of prices are exact.
+//
+// The zero value is a null price, which is what Scryfall returns when
+// a card has no known price.
+type Price struct {
+	units int64
+	valid bool
+}
+
+// NewPrice creates a Price from a number of hundredths of a currency unit, e.g. cents.
+func NewPrice(hundredths int64) Price {
+	return Price{units: hundredths * (priceScale / 100), valid: true}
+}
+
+// ParsePrice parses a decimal string like "0.44" into a Price.
+// An empty string parses to a null Price.
+func ParsePrice(txt string) (Price, error) {
+	if txt == "" {
+		return Price{}, nil
+	}
+
+	whole, fraction, hasFraction := strings.Cut(txt, ".")
+
+	negative := strings.HasPrefix(whole, "-")
+	whole = strings.TrimPrefix(whole, "-")
+
+	if whole == "" || !isDigits(whole) || (hasFraction && (fraction == "" || !isDigits(fraction))) {
+		return Price{}, fmt.Errorf("%w: %q", ErrInvalidPrice, txt)
+	}
+
+	if len(fraction) > priceDecimals {
+		return Price{}, fmt.Errorf("%w: %q has more than %d decimal places", ErrInvalidPrice, txt, priceDecimals)
+	}
+
+	wholeUnits, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return Price{}, fmt.Errorf("%w: %q: %v", ErrInvalidPrice, txt, err)
+	}
+
+	fraction += strings.Repeat("0", priceDecimals-len(fraction))
+
+	fractionUnits, err := strconv.ParseInt(fraction, 10, 64)
+	if err != nil {
+		return Price{}, fmt.Errorf("%w: %q: %v", ErrInvalidPrice, txt, err)
+	}
+
+	units := wholeUnits*priceScale + fractionUnits
+	if negative {
+		units = -units
+	}
+
+	return Price{units: units, valid: true}, nil
+}
+
+func isDigits(txt string) bool {
+	for _, r := range txt {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Valid returns false if the price is null, i.e. unknown.
+func (p Price) Valid() bool {
+	return p.valid
+}
+
+// Hundredths returns the price in hundredths of a currency unit, e.g. cents,
+// truncating any further decimal places.  It returns 0 for a null price.
+func (p Price) Hundredths() int64 {
+	return p.units / (priceScale / 100)
+}
+
+// Float64 returns the price as a float64 for display or
+// approximate calculations.  It returns 0 for a null price.
+func (p Price) Float64() float64 {
+	return float64(p.units) / priceScale
+}
+
+// Add returns the sum of two prices.  The result is null only if both are null.
+func (p Price) Add(other Price) Price {
+	return Price{units: p.units + other.units, valid: p.valid || other.valid}
+}
+
+// Mul returns the price multiplied by quantity.
+func (p Price) Mul(quantity int) Price {
+	return Price{units: p.units * int64(quantity), valid: p.valid}
+}
+
+// Less reports whether p is cheaper than other.  Null prices
+// sort after every valid price, as they do on Scryfall.
+func (p Price) Less(other Price) bool {
+	if p.valid != other.valid {
+		return p.valid
+	}
+
+	return p.units < other.units
+}
+
+// String returns the price as a decimal string with at least two decimal
+// places, e.g. "0.44".  It returns an empty string for a null price.
+func (p Price) String() string {
+	if !p.valid {
+		return ""
+	}
+
+	units := p.units
+	sign := ""
+
+	if units < 0 {
+		sign = "-"
+		units = -units
+	}
+
+	fraction := fmt.Sprintf("%04d", units%priceScale)
+	fraction = strings.TrimRight(fraction, "0")
+
+	for len(fraction) < 2 {
+		fraction += "0"
+	}
+
+	return fmt.Sprintf("%s%d.%s", sign, units/priceScale, fraction)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (p *Price) UnmarshalText(txt []byte) error {
+	parsed, err := ParsePrice(string(txt))
+	if err != nil {
+		return err
+	}
+
+	*p = parsed
+
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It accepts a decimal string or null.
+func (p *Price) UnmarshalJSON(txt []byte) error {
+	var unmarshed *string
+
+	err := json.Unmarshal(txt, &unmarshed)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal price: %w", err)
+	}
+
+	if unmarshed == nil {
+		*p = Price{}
+
+		return nil
+	}
+
+	return p.UnmarshalText([]byte(*unmarshed))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (p Price) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Null prices are marshalled as null.
+func (p Price) MarshalJSON() ([]byte, error) {
+	if !p.valid {
+		return []byte("null"), nil
+	}
+
+	marshalled, err := json.Marshal(p.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal price: %w", err)
+	}
+
+	return marshalled, nil
+}
+
+// Currency is a currency that Scryfall reports prices in.
+type Currency string
+
+const (
+	// CurrencyUSD is U.S. Dollars, from TCGplayer.
+	CurrencyUSD Currency = "usd"
+
+	// CurrencyEUR is Euros, from Cardmarket.
+	CurrencyEUR Currency = "eur"
+
+	// CurrencyTIX is MTGO event tickets, from Cardhoarder.
+	CurrencyTIX Currency = "tix"
+)
+
+// AllCurrencies returns all possible values of Currency.
+func AllCurrencies() []Currency {
+	return []Currency{CurrencyUSD, CurrencyEUR, CurrencyTIX}
+}
+
+// Finish is a finish a card can be printed in, as listed in Card.Finishes.
+type Finish string
+
+const (
+	// FinishNonfoil is a regular, non-foil printing.
+	FinishNonfoil Finish = "nonfoil"
+
+	// FinishFoil is a traditional foil printing.
+	FinishFoil Finish = "foil"
+
+	// FinishEtched is an etched foil printing.
+	FinishEtched Finish = "etched"
+)
+
+// AllFinishes returns all possible values of Finish.
+func AllFinishes() []Finish {
+	return []Finish{FinishNonfoil, FinishFoil, FinishEtched}
+}
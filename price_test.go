@@ -0,0 +1,151 @@
+package gofall_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/SethCurry/gofall"
+)
+
+func Test_ParsePrice(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		txt     string
+		want    string
+		valid   bool
+		wantErr bool
+	}{
+		{name: "cents", txt: "0.44", want: "0.44", valid: true},
+		{name: "whole", txt: "12", want: "12.00", valid: true},
+		{name: "one decimal", txt: "1.5", want: "1.50", valid: true},
+		{name: "four decimals", txt: "0.0125", want: "0.0125", valid: true},
+		{name: "negative", txt: "-3.10", want: "-3.10", valid: true},
+		{name: "empty", txt: "", want: "", valid: false},
+		{name: "too precise", txt: "0.00001", wantErr: true},
+		{name: "not a number", txt: "abc", wantErr: true},
+		{name: "trailing dot", txt: "1.", wantErr: true},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.name, func(t *testing.T) {
+			t.Parallel()
+
+			price, err := gofall.ParsePrice(v.txt)
+			if (err != nil) != v.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if err != nil {
+				if !errors.Is(err, gofall.ErrInvalidPrice) {
+					t.Errorf("expected ErrInvalidPrice, got %v", err)
+				}
+
+				return
+			}
+
+			if price.Valid() != v.valid {
+				t.Errorf("unexpected validity: got %v, want %v", price.Valid(), v.valid)
+			}
+
+			if price.String() != v.want {
+				t.Errorf("unexpected string: got %q, want %q", price.String(), v.want)
+			}
+		})
+	}
+}
+
+func Test_Price_Arithmetic(t *testing.T) {
+	t.Parallel()
+
+	// 0.1 + 0.2 is not exact with floats.
+	a, _ := gofall.ParsePrice("0.10")
+	b, _ := gofall.ParsePrice("0.20")
+
+	if sum := a.Add(b); sum.String() != "0.30" || sum.Hundredths() != 30 {
+		t.Errorf("unexpected sum: %v", sum)
+	}
+
+	if total := a.Mul(4); total.String() != "0.40" {
+		t.Errorf("unexpected product: %v", total)
+	}
+
+	if !a.Less(b) || b.Less(a) {
+		t.Errorf("expected %v to be less than %v", a, b)
+	}
+
+	if !a.Less(gofall.Price{}) || (gofall.Price{}).Less(a) {
+		t.Errorf("expected null prices to sort last")
+	}
+}
+
+func Test_Prices_JSON(t *testing.T) {
+	t.Parallel()
+
+	var prices gofall.Prices
+
+	err := json.Unmarshal([]byte(`{"usd":"0.44","usd_foil":"4.48","usd_etched":null,"eur":"0.10","tix":null}`), &prices)
+	if err != nil {
+		t.Fatalf("failed to unmarshal prices: %v", err)
+	}
+
+	if got := prices.Get(gofall.CurrencyUSD, gofall.FinishFoil); got.String() != "4.48" {
+		t.Errorf("unexpected foil price: %v", got)
+	}
+
+	if got := prices.Get(gofall.CurrencyUSD, gofall.FinishEtched); got.Valid() {
+		t.Errorf("expected null etched price, got %v", got)
+	}
+
+	if got := prices.Get(gofall.CurrencyTIX, gofall.FinishFoil); got.Valid() {
+		t.Errorf("expected null TIX price, got %v", got)
+	}
+
+	cheapest, finish := prices.Cheapest(gofall.CurrencyUSD)
+	if cheapest.String() != "0.44" || finish != gofall.FinishNonfoil {
+		t.Errorf("unexpected cheapest price: %v %v", cheapest, finish)
+	}
+
+	marshalled, err := json.Marshal(prices)
+	if err != nil {
+		t.Fatalf("failed to marshal prices: %v", err)
+	}
+
+	expected := `{"usd":"0.44","usd_foil":"4.48","usd_etched":null,"eur":"0.10","eur_foil":null,"eur_etched":null,"tix":null}`
+	if string(marshalled) != expected {
+		t.Errorf("unexpected JSON: got %s, want %s", marshalled, expected)
+	}
+}
+
+func Test_TotalCheapestPrinting(t *testing.T) {
+	t.Parallel()
+
+	printings := readAllCards(t)
+
+	// Add a cheaper foil-only printing of the first card.
+	cheaper := printings[0]
+	cheaper.Prices = gofall.Prices{USDFoil: gofall.NewPrice(5)}
+	printings = append(printings, cheaper)
+
+	total := gofall.TotalCheapestPrinting(map[string]int{
+		"Fury Sliver":   4,
+		"Kor Outfitter": 2,
+		"Not A Card":    1,
+	}, printings, gofall.CurrencyUSD)
+
+	// 4 * 0.05 + 2 * 0.26
+	if total.Total.String() != "0.72" {
+		t.Errorf("unexpected total: %v", total.Total)
+	}
+
+	if len(total.Missing) != 1 || total.Missing[0] != "Not A Card" {
+		t.Errorf("unexpected missing cards: %v", total.Missing)
+	}
+
+	sum := gofall.TotalPrice(printings[:2], gofall.CurrencyUSD)
+	if sum.Total.String() != "0.70" || len(sum.Missing) != 0 {
+		t.Errorf("unexpected price total: %+v", sum)
+	}
+}
@@ -0,0 +1,87 @@
+package gofall
+
+import "sort"
+
+// PriceTotal is the result of totaling the prices of several cards.
+type PriceTotal struct {
+	// Total is the sum of every known price.  It is null if no price was known.
+	Total Price
+
+	// Missing lists the names of cards that had no price in the
+	// requested currency, and so are not included in Total.
+	Missing []string
+}
+
+// TotalPrice totals the cheapest price in currency of each card, across
+// finishes.  Each card is counted once.
+func TotalPrice(cards []Card, currency Currency) PriceTotal {
+	var total PriceTotal
+
+	for _, card := range cards {
+		price, _ := card.Prices.Cheapest(currency)
+		if !price.Valid() {
+			total.Missing = append(total.Missing, card.Name)
+
+			continue
+		}
+
+		total.Total = total.Total.Add(price)
+	}
+
+	return total
+}
+
+// CheapestPrinting returns the printing with the cheapest price in currency,
+// across finishes, along with its price and finish.  It returns nil if none
+// of the printings have a price in that currency.
+func CheapestPrinting(printings []Card, currency Currency) (*Card, Price, Finish) {
+	var (
+		cheapest      *Card
+		cheapestPrice Price
+		finish        Finish
+	)
+
+	for i := range printings {
+		price, priceFinish := printings[i].Prices.Cheapest(currency)
+		if price.Less(cheapestPrice) {
+			cheapest = &printings[i]
+			cheapestPrice = price
+			finish = priceFinish
+		}
+	}
+
+	return cheapest, cheapestPrice, finish
+}
+
+// TotalCheapestPrinting totals a list of cards given as quantities by card
+// name, such as a deck list, pricing each card at its cheapest printing in
+// printings.  printings would typically come from a search with UniquePrint
+// or from the default_cards bulk data.
+//
+// Names with no priced printing are listed in Missing, sorted by name.
+func TotalCheapestPrinting(quantities map[string]int, printings []Card, currency Currency) PriceTotal {
+	byName := map[string][]Card{}
+
+	for _, printing := range printings {
+		if _, ok := quantities[printing.Name]; ok {
+			byName[printing.Name] = append(byName[printing.Name], printing)
+		}
+	}
+
+	var total PriceTotal
+
+	for name, quantity := range quantities {
+		_, price, _ := CheapestPrinting(byName[name], currency)
+		if !price.Valid() {
+			total.Missing = append(total.Missing, name)
+
+			continue
+		}
+
+		total.Total = total.Total.Add(price.Mul(quantity))
+	}
+
+	sort.Strings(total.Missing)
+
+	return total
+}
@@ -0,0 +1,51 @@
+package gofall
+
+import "context"
+
+// Priority decides which requests the rate limiter lets through first
+// when requests are waiting for it.  Set it for a call with WithPriority.
+type Priority int
+
+const (
+	// PriorityLow is for background work, such as batch jobs, that
+	// should not hold up other requests.
+	PriorityLow Priority = -1
+
+	// PriorityNormal is the priority of requests without one set.
+	PriorityNormal Priority = 0
+
+	// PriorityHigh is for interactive requests, such as autocompletion,
+	// that someone is waiting on.
+	PriorityHigh Priority = 1
+)
+
+// priorities are the priorities in the order they are served.
+var priorities = []Priority{PriorityHigh, PriorityNormal, PriorityLow}
+
+// fairnessInterval is how many slots in a row the rate limiter gives
+// to higher priorities while lower priorities are waiting, before
+// giving one to the oldest lower priority request.  Lower priorities
+// therefore get at least one in every fairnessInterval+1 slots.
+const fairnessInterval = 4
+
+type priorityKey struct{}
+
+// WithPriority returns a copy of ctx that gives requests made with it
+// the priority p.  While requests are waiting for the rate limiter,
+// higher priorities are let through first, but lower priorities still
+// get a share of the requests so they are never starved.  A request
+// waiting behind others keeps its place for as long as ctx allows, so
+// use a deadline to bound how long low priority work can be held up.
+// The overall rate of requests is the same whatever their priorities.
+//
+// Priorities other than PriorityLow, PriorityNormal and PriorityHigh are
+// treated as the nearest of them.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+func priorityFrom(ctx context.Context) Priority {
+	p, _ := ctx.Value(priorityKey{}).(Priority)
+
+	return min(max(p, PriorityLow), PriorityHigh)
+}
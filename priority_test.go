@@ -0,0 +1,159 @@
+package gofall
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func Test_PriorityFrom(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		ctx  context.Context
+		want Priority
+	}{
+		{
+			name: "unset",
+			ctx:  context.Background(),
+			want: PriorityNormal,
+		},
+		{
+			name: "high",
+			ctx:  WithPriority(context.Background(), PriorityHigh),
+			want: PriorityHigh,
+		},
+		{
+			name: "above high",
+			ctx:  WithPriority(context.Background(), 10),
+			want: PriorityHigh,
+		},
+		{
+			name: "below low",
+			ctx:  WithPriority(context.Background(), -10),
+			want: PriorityLow,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := priorityFrom(v.ctx); got != v.want {
+				t.Errorf("unexpected priority: got %v, want %v", got, v.want)
+			}
+		})
+	}
+}
+
+func Test_RateLimiter_Priority(t *testing.T) {
+	t.Parallel()
+
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	limiter := newRateLimiter(time.Second, 1, clock)
+	tryTake(limiter)
+
+	var tickets []*limiterTicket
+
+	// Low priority requests arrive first, but high priority
+	// requests are let through ahead of them.
+	for i := 0; i < 2; i++ {
+		tickets = append(tickets, limiter.enqueue(PriorityLow))
+	}
+
+	for i := 0; i < 10; i++ {
+		tickets = append(tickets, limiter.enqueue(PriorityHigh))
+	}
+
+	tickets = append(tickets, limiter.enqueue(PriorityNormal))
+
+	var order []Priority
+
+	for len(order) < len(tickets) {
+		clock.Advance(time.Second)
+
+		taken := false
+
+		for _, ticket := range tickets {
+			limiter.lock.Lock()
+			limiter.clean()
+			ok := limiter.take(ticket)
+			limiter.lock.Unlock()
+
+			if ok {
+				order = append(order, ticket.priority)
+				taken = true
+
+				break
+			}
+		}
+
+		if !taken {
+			t.Fatalf("expected a request to be given the free slot after %v", order)
+		}
+	}
+
+	want := []Priority{
+		PriorityHigh, PriorityHigh, PriorityHigh, PriorityHigh, PriorityLow,
+		PriorityHigh, PriorityHigh, PriorityHigh, PriorityHigh, PriorityLow,
+		PriorityHigh, PriorityHigh, PriorityNormal,
+	}
+
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("unexpected order: got %v, want %v", order, want)
+		}
+	}
+}
+
+func Test_RateLimiter_WaitInTurn(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	limiter := newRateLimiter(time.Second, 1, clock)
+
+	high := limiter.enqueue(PriorityHigh)
+	low := limiter.enqueue(PriorityLow)
+
+	// The free slot is kept for the high priority request.
+	ok, err := limiter.wait(ctx, low, 100*time.Millisecond)
+	if err != nil || ok {
+		t.Fatalf("expected the low priority request to wait its turn, got %v, %v", ok, err)
+	}
+
+	ok, err = limiter.wait(ctx, high, 0)
+	if err != nil || !ok {
+		t.Fatalf("expected the high priority request to get the slot, got %v, %v", ok, err)
+	}
+
+	// The low priority request gets the next slot once the window passes.
+	start := clock.Now()
+
+	ok, err = limiter.wait(ctx, low, 10*time.Second)
+	if err != nil || !ok {
+		t.Fatalf("expected the low priority request to get a slot, got %v, %v", ok, err)
+	}
+
+	if waited := clock.Now().Sub(start); waited > time.Second {
+		t.Errorf("expected a slot within the window, waited %v", waited)
+	}
+
+	// A request that gives up leaves the queue.
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	abandoned := limiter.enqueue(PriorityHigh)
+
+	if _, err := limiter.wait(cancelled, abandoned, time.Second); err == nil {
+		t.Errorf("expected an error from a cancelled context")
+	}
+
+	limiter.leave(abandoned)
+	clock.Advance(time.Second)
+
+	if !tryTake(limiter) {
+		t.Errorf("expected the abandoned request to have left the queue")
+	}
+}
@@ -0,0 +1,127 @@
+package gofall
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// ResponseMeta describes the response to a call, beyond the data the
+// call returns.  Capture it by passing a context from WithResponseMeta.
+type ResponseMeta struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+
+	// Header is the response's headers.
+	Header http.Header
+
+	// Latency is how long the request took by the Client's Clock,
+	// including time spent waiting for the rate limiter and retrying.
+	Latency time.Duration
+
+	// Retries is the number of times the request was retried.
+	Retries int
+
+	// CacheHit is true if the response came from a Cache, either
+	// because it was fresh or because Scryfall confirmed it was unchanged.
+	CacheHit bool
+
+	// Warnings are Scryfall's warnings about the request, such as
+	// search terms it ignored.
+	Warnings []string
+
+	// TotalCards is the total number of cards matching a search,
+	// across all pages.  It is only set for lists of cards.
+	TotalCards int
+
+	// HasMore is true if a list has more pages.
+	HasMore bool
+}
+
+type responseMetaKey struct{}
+
+// WithResponseMeta returns a copy of ctx that makes calls made with it
+// fill in meta, including calls that fail with an *APIError.
+//
+// If a call makes several requests, such as Collection with more than 75
+// identifiers, meta describes the last of them, except for Warnings,
+// which are collected from all of them.  Use a new ResponseMeta for
+// each call.
+//
+//	var meta gofall.ResponseMeta
+//
+//	cards, err := pager.Next(gofall.WithResponseMeta(ctx, &meta))
+//	for _, warning := range meta.Warnings {
+//		fmt.Println(warning)
+//	}
+func WithResponseMeta(ctx context.Context, meta *ResponseMeta) context.Context {
+	return context.WithValue(ctx, responseMetaKey{}, meta)
+}
+
+// withoutResponseMeta returns a copy of ctx that does not capture
+// metadata, for requests made in the background of a call.
+func withoutResponseMeta(ctx context.Context) context.Context {
+	return context.WithValue(ctx, responseMetaKey{}, (*ResponseMeta)(nil))
+}
+
+func responseMetaFrom(ctx context.Context) *ResponseMeta {
+	meta, _ := ctx.Value(responseMetaKey{}).(*ResponseMeta)
+
+	return meta
+}
+
+// requestTrace is filled in by the transports beneath a Client's
+// http.Client, which have no other way to report back to the caller.
+type requestTrace struct {
+	attempts int
+	cacheHit bool
+}
+
+type requestTraceKey struct{}
+
+func withRequestTrace(ctx context.Context, trace *requestTrace) context.Context {
+	return context.WithValue(ctx, requestTraceKey{}, trace)
+}
+
+// traceFrom returns the request's trace, or one that is discarded if
+// nobody is interested in it.
+func traceFrom(ctx context.Context) *requestTrace {
+	if trace, ok := ctx.Value(requestTraceKey{}).(*requestTrace); ok {
+		return trace
+	}
+
+	return &requestTrace{}
+}
+
+// listMeta is implemented by responses that describe a list.
+type listMeta interface {
+	listMeta() (warnings []string, totalCards int, hasMore bool)
+}
+
+func (l *listContainer[T]) listMeta() ([]string, int, bool) {
+	return l.Warnings, l.TotalCards, l.HasMore
+}
+
+// recordResponse fills in meta from a response, which may be nil if
+// the request failed.
+func (r *ResponseMeta) recordResponse(resp *http.Response, trace *requestTrace, latency time.Duration) {
+	r.Latency = latency
+	r.Retries = max(trace.attempts-1, 0)
+	r.CacheHit = trace.cacheHit
+	r.StatusCode = 0
+	r.Header = nil
+
+	if resp != nil {
+		r.StatusCode = resp.StatusCode
+		r.Header = resp.Header.Clone()
+	}
+}
+
+// recordList fills in meta from a decoded list.
+func (r *ResponseMeta) recordList(list listMeta) {
+	warnings, totalCards, hasMore := list.listMeta()
+
+	r.Warnings = append(r.Warnings, warnings...)
+	r.TotalCards = totalCards
+	r.HasMore = hasMore
+}
@@ -0,0 +1,152 @@
+package gofall_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/SethCurry/gofall"
+	"github.com/SethCurry/gofall/scryfalltest"
+)
+
+func newFakeServer(t *testing.T, opts scryfalltest.Options) *scryfalltest.Server {
+	t.Helper()
+
+	cards, err := scryfalltest.LoadCards("test/cards.json")
+	if err != nil {
+		t.Fatalf("failed to load cards: %v", err)
+	}
+
+	opts.Cards = cards
+
+	server := scryfalltest.NewServer(opts)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func Test_ResponseMeta_Search(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeServer(t, scryfalltest.Options{PageSize: 2}).Client()
+
+	pager, err := client.Card.Search(context.Background(), "t:creature usd>1", gofall.CardSearchOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var meta gofall.ResponseMeta
+
+	cards, err := pager.Next(gofall.WithResponseMeta(context.Background(), &meta))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if meta.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", meta.StatusCode)
+	}
+
+	if meta.Header.Get("Content-Type") == "" {
+		t.Errorf("expected response headers, got %v", meta.Header)
+	}
+
+	if len(meta.Warnings) != 1 {
+		t.Errorf("expected a warning about the ignored term, got %v", meta.Warnings)
+	}
+
+	if meta.TotalCards <= len(cards) || !meta.HasMore {
+		t.Errorf("expected more cards than the first page, got %d total with has_more %v", meta.TotalCards, meta.HasMore)
+	}
+
+	if meta.Latency <= 0 || meta.Retries != 0 || meta.CacheHit {
+		t.Errorf("unexpected timing %+v", meta)
+	}
+}
+
+func Test_ResponseMeta_Error(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeServer(t, scryfalltest.Options{}).Client()
+
+	pager, err := client.Card.Search(context.Background(), "usd>1", gofall.CardSearchOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var meta gofall.ResponseMeta
+
+	if _, err := pager.Next(gofall.WithResponseMeta(context.Background(), &meta)); !errors.Is(err, gofall.ErrBadRequest) {
+		t.Fatalf("expected ErrBadRequest, got %v", err)
+	}
+
+	if meta.StatusCode != http.StatusBadRequest || len(meta.Warnings) != 1 {
+		t.Errorf("expected the error's status and warnings, got %+v", meta)
+	}
+
+	// Looking up candidates for an ambiguous name must not replace
+	// the metadata of the failed lookup.
+	meta = gofall.ResponseMeta{}
+
+	_, err = client.Card.Named(gofall.WithResponseMeta(context.Background(), &meta), gofall.CardNamedRequest{Fuzzy: "s"})
+	if !errors.Is(err, gofall.ErrAmbiguous) {
+		t.Fatalf("expected ErrAmbiguous, got %v", err)
+	}
+
+	if meta.StatusCode != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", meta.StatusCode)
+	}
+}
+
+func Test_ResponseMeta_CacheHit(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeServer(t, scryfalltest.Options{})
+	client := gofall.NewClientWithOptions(gofall.ClientOptions{
+		HTTPClient: &http.Client{Transport: server.Transport()},
+		Cache:      gofall.NewCache(gofall.CacheOptions{}),
+	})
+
+	for i, wantHit := range []bool{false, true} {
+		var meta gofall.ResponseMeta
+
+		ctx := gofall.WithResponseMeta(context.Background(), &meta)
+
+		if _, err := client.Card.Named(ctx, gofall.CardNamedRequest{Exact: "Web"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if meta.CacheHit != wantHit {
+			t.Errorf("request %d: expected cache hit %v, got %v", i, wantHit, meta.CacheHit)
+		}
+	}
+}
+
+func Test_ResponseMeta_Latency(t *testing.T) {
+	t.Parallel()
+
+	clock := gofall.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	server := newFakeServer(t, scryfalltest.Options{})
+	client := gofall.NewClientWithOptions(gofall.ClientOptions{
+		HTTPClient:        &http.Client{Transport: server.Transport()},
+		DisableCoalescing: true,
+		Clock:             clock,
+	})
+
+	// The fake clock does not move while requests are made, until the
+	// rate limit is reached and the client has to wait for it.
+	for i, wantWait := range []bool{false, false, false, false, false, true} {
+		var meta gofall.ResponseMeta
+
+		ctx := gofall.WithResponseMeta(context.Background(), &meta)
+
+		if _, err := client.Card.Named(ctx, gofall.CardNamedRequest{Exact: "Web"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if waited := meta.Latency > 0; waited != wantWait || meta.Latency > time.Second {
+			t.Errorf("request %d: unexpected latency %v", i, meta.Latency)
+		}
+	}
+}
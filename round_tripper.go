@@ -1,6 +1,7 @@
 package gofall
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"sync"
@@ -22,87 +23,351 @@ func (r *RoundTripperError) Error() string {
 	return "round tripper failed: " + r.Inner.Error()
 }
 
+// Unwrap returns the error that caused the request to fail.
+func (r *RoundTripperError) Unwrap() error {
+	return r.Inner
+}
+
 type roundTripper struct {
 	inner      http.RoundTripper
 	limiter    *rateLimiter
 	maxRetries int
+	observer   observer
+	clock      Clock
 }
 
 func (r *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	clock := r.clock
+	if clock == nil {
+		clock = SystemClock{}
+	}
+
+	start := clock.Now()
+	trace := traceFrom(ctx)
 	numAttempts := 0
 	lastSleep := time.Second
 
+	hooks := r.observer
+	if hooks.hooks == nil {
+		// The zero value reports to NoopHooks and logs nothing.
+		hooks = newObserver(nil, nil)
+	}
+
+	hooks.requestStart(ctx, RequestStartEvent{Method: req.Method, URL: req.URL.String()})
+
+	// The request keeps its place in the queue for a slot between attempts.
+	ticket := r.limiter.enqueue(priorityFrom(ctx))
+	defer r.limiter.leave(ticket)
+
+	end := func(status int, bytes int64, err error) {
+		hooks.requestEnd(ctx, RequestEndEvent{
+			Method:     req.Method,
+			URL:        req.URL.String(),
+			StatusCode: status,
+			Duration:   clock.Now().Sub(start),
+			Bytes:      bytes,
+			Attempts:   numAttempts,
+			Err:        err,
+		})
+	}
+
 	for numAttempts < r.maxRetries {
 		numAttempts++
+		trace.attempts = numAttempts
+
+		ok, err := r.limiter.wait(ctx, ticket, lastSleep)
+		if err != nil {
+			err = newRoundTripperError(err)
+			end(0, 0, err)
+
+			return nil, err
+		}
 
-		ok := r.limiter.AddEvent()
 		if !ok {
-			time.Sleep(lastSleep)
+			if numAttempts < r.maxRetries {
+				hooks.retry(ctx, RetryEvent{
+					URL:     req.URL.String(),
+					Attempt: numAttempts + 1,
+					Reason:  "rate limiter full",
+					Backoff: lastSleep,
+				})
+			}
+
 			lastSleep *= 2
 
 			continue
 		}
 
+		if ticket.slept {
+			hooks.limiterWait(ctx, LimiterWaitEvent{URL: req.URL.String(), Wait: clock.Now().Sub(start)})
+		}
+
 		resp, err := r.inner.RoundTrip(req)
 		if err != nil {
-			return nil, newRoundTripperError(err)
+			err = newRoundTripperError(err)
+			end(0, 0, err)
+
+			return nil, err
+		}
+
+		resp.Body = &observedBody{
+			ReadCloser: resp.Body,
+			end:        func(bytes int64) { end(resp.StatusCode, bytes, nil) },
 		}
 
 		return resp, nil
 	}
 
+	end(0, 0, ErrTimeoutFromLimiter)
+
 	return nil, ErrTimeoutFromLimiter
 }
 
 // ErrTimeoutFromLimiter is returned when a request is timed out while waiting for
-// a request in the rate limiter to be available.
+// a request in the rate limiter to be available.  Requests only time out if
+// the rate limiter lets no requests through for the whole of their retries;
+// a request queued behind others waits for as long as its context allows.
 var ErrTimeoutFromLimiter = errors.New("timed out while waiting for available request in rate limiter")
 
-func newRateLimiter(window time.Duration, maxPerPeriod int) *rateLimiter {
+func newRateLimiter(window time.Duration, maxPerPeriod int, clock Clock) *rateLimiter {
 	return &rateLimiter{
+		clock:        clock,
 		window:       window,
 		maxPerPeriod: maxPerPeriod,
 		events:       []time.Time{},
-		lock:         sync.RWMutex{},
+		waiting:      map[Priority][]*limiterTicket{},
 	}
 }
 
+// rateLimiter allows maxPerPeriod requests in any window of time.
+// Requests waiting for a slot are served by priority, see schedule.
 type rateLimiter struct {
+	clock        Clock
 	window       time.Duration
 	maxPerPeriod int
 	events       []time.Time
-	lock         sync.RWMutex
+	lock         sync.Mutex
+
+	// waiting holds the requests waiting for a slot by priority,
+	// oldest first.
+	waiting map[Priority][]*limiterTicket
+	nextSeq uint64
+
+	// passedOver counts the slots given to a higher priority in a
+	// row while lower priorities were waiting.
+	passedOver int
+
+	// granted counts the slots given out, so waiting requests can
+	// tell whether the queue is moving.
+	granted uint64
 }
 
-func (r *rateLimiter) AddEvent() bool {
-	r.Clean()
-	r.lock.Lock()
-	defer r.lock.Unlock()
+// limiterTicket is a request's place in the queue for a slot.
+type limiterTicket struct {
+	priority Priority
+	seq      uint64
 
-	if len(r.events) >= r.maxPerPeriod {
-		return false
+	// slept is true once the request has had to wait.
+	slept bool
+}
+
+// limiterPollInterval is how often a request waiting behind others
+// checks whether it can have a free slot.
+const limiterPollInterval = 10 * time.Millisecond
+
+// clean forgets events that have left the window.  The lock must be held.
+func (r *rateLimiter) clean() {
+	cutoff := r.clock.Now().Add(-r.window)
+	kept := r.events[:0]
+
+	for _, event := range r.events {
+		if event.After(cutoff) {
+			kept = append(kept, event)
+		}
 	}
 
-	r.events = append(r.events, time.Now())
+	r.events = kept
+}
 
-	return true
+// enqueue adds a request to the queue for a slot.  The caller must
+// call leave when it is done with the ticket.
+func (r *rateLimiter) enqueue(priority Priority) *limiterTicket {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.nextSeq++
+	ticket := &limiterTicket{priority: priority, seq: r.nextSeq}
+	r.waiting[priority] = append(r.waiting[priority], ticket)
+
+	return ticket
 }
 
-func (r *rateLimiter) Clean() {
+// leave removes a request from the queue, if it is still waiting.
+func (r *rateLimiter) leave(ticket *limiterTicket) {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
-	now := time.Now()
+	r.remove(ticket)
+}
+
+// remove removes a ticket from the queue.  The lock must be held.
+func (r *rateLimiter) remove(ticket *limiterTicket) {
+	tickets := r.waiting[ticket.priority]
+
+	for i, waiting := range tickets {
+		if waiting == ticket {
+			r.waiting[ticket.priority] = append(tickets[:i], tickets[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// wait waits for the ticket to be given a slot, and reports whether it
+// was.  It gives up once timeout passes without any slot being given
+// out, but keeps waiting while slots go to requests ahead of the ticket,
+// as it is still moving up the queue.  It only returns an error if ctx
+// is done.
+func (r *rateLimiter) wait(ctx context.Context, ticket *limiterTicket, timeout time.Duration) (bool, error) {
+	var (
+		deadline time.Time
+		granted  uint64
+	)
+
+	for {
+		r.lock.Lock()
+		r.clean()
+
+		now := r.clock.Now()
+
+		if r.take(ticket) {
+			r.lock.Unlock()
+
+			return true, nil
+		}
+
+		if deadline.IsZero() || r.granted != granted {
+			deadline = now.Add(timeout)
+			granted = r.granted
+		}
+
+		// Sleep until the oldest slot frees up, or briefly if there
+		// are free slots that requests ahead of this one will take.
+		sleep := limiterPollInterval
+		if len(r.events) >= r.maxPerPeriod {
+			sleep = r.events[0].Add(r.window).Sub(now)
+		}
+
+		r.lock.Unlock()
+
+		remaining := deadline.Sub(now)
+		if remaining <= 0 {
+			return false, nil
+		}
+
+		ticket.slept = true
+
+		if err := r.clock.Sleep(ctx, min(sleep, remaining)); err != nil {
+			return false, err
+		}
+	}
+}
+
+// take gives the ticket a slot if one is free and it is the ticket's
+// turn.  The lock must be held.
+func (r *rateLimiter) take(ticket *limiterTicket) bool {
+	free := r.maxPerPeriod - len(r.events)
+
+	for _, next := range r.schedule(free) {
+		if next != ticket {
+			continue
+		}
+
+		_, passedOver := r.pick(map[Priority]int{}, r.passedOver, ticket)
+		r.passedOver = passedOver
+
+		r.remove(ticket)
+		r.events = append(r.events, r.clock.Now())
+		r.granted++
+
+		return true
+	}
+
+	return false
+}
+
+// schedule returns the next n waiting requests in the order they will
+// be given slots.  The lock must be held.
+func (r *rateLimiter) schedule(n int) []*limiterTicket {
+	var order []*limiterTicket
+
+	taken := map[Priority]int{}
+	passedOver := r.passedOver
+
+	for len(order) < n {
+		var next *limiterTicket
+
+		next, passedOver = r.pick(taken, passedOver, nil)
+		if next == nil {
+			break
+		}
+
+		taken[next.priority]++
+
+		order = append(order, next)
+	}
+
+	return order
+}
+
+// pick chooses the request to give the next slot to, skipping the
+// first taken[p] requests of each priority p, and returns it with the
+// new value of passedOver.
+//
+// The oldest request of the highest priority waiting is normally
+// chosen.  But once fairnessInterval slots in a row have gone to a
+// higher priority while lower priorities were waiting, the oldest of
+// the lower priority requests is chosen instead, so they still make
+// progress.
+//
+// If chosen is not nil, it is the request being given the slot, and
+// only the new value of passedOver is calculated.
+func (r *rateLimiter) pick(
+	taken map[Priority]int,
+	passedOver int,
+	chosen *limiterTicket,
+) (*limiterTicket, int) {
+	var highest, oldestLower *limiterTicket
+
+	for _, priority := range priorities {
+		tickets := r.waiting[priority]
+		if taken[priority] >= len(tickets) {
+			continue
+		}
 
-	indexesToRemove := []int{}
+		head := tickets[taken[priority]]
 
-	for i, event := range r.events {
-		if event.Before(now.Add(-r.window)) {
-			indexesToRemove = append(indexesToRemove, i)
+		switch {
+		case highest == nil:
+			highest = head
+		case oldestLower == nil || head.seq < oldestLower.seq:
+			oldestLower = head
 		}
 	}
 
-	for i, idx := range indexesToRemove {
-		r.events = append(r.events[:idx-i], r.events[idx-i+1:]...)
+	switch {
+	case highest == nil:
+		return nil, passedOver
+	case chosen != nil && chosen.priority != highest.priority:
+		// The chosen request was let ahead of higher priorities.
+		return chosen, 0
+	case chosen == nil && oldestLower != nil && passedOver >= fairnessInterval:
+		return oldestLower, 0
+	case oldestLower != nil:
+		return highest, passedOver + 1
+	default:
+		return highest, 0
 	}
 }
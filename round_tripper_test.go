@@ -1,17 +1,31 @@
 package gofall
 
 import (
+	"context"
+	"errors"
+	"net/http"
 	"testing"
 	"time"
 )
 
+// tryTake takes a slot for a request that does not wait for one.
+func tryTake(limiter *rateLimiter) bool {
+	ticket := limiter.enqueue(PriorityNormal)
+	defer limiter.leave(ticket)
+
+	ok, _ := limiter.wait(context.Background(), ticket, 0)
+
+	return ok
+}
+
 func Test_RateLimiter(t *testing.T) {
 	t.Parallel()
 
-	limiter := newRateLimiter(time.Second, 1)
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	limiter := newRateLimiter(time.Second, 1, clock)
 
 	for i := 0; i < 5; i++ {
-		canRun := limiter.AddEvent()
+		canRun := tryTake(limiter)
 		if i == 0 {
 			if !canRun {
 				t.Error("did not expect to be throttled here")
@@ -23,15 +37,153 @@ func Test_RateLimiter(t *testing.T) {
 		}
 	}
 
-	time.Sleep(time.Second)
+	clock.Advance(time.Second + time.Millisecond)
 
-	canRun := limiter.AddEvent()
+	canRun := tryTake(limiter)
 	if !canRun {
 		t.Error("did not expect to be throttled")
 	}
 
-	canRun = limiter.AddEvent()
+	canRun = tryTake(limiter)
 	if canRun {
 		t.Error("expected to be throttled")
 	}
 }
+
+// retryHooks records the retries a roundTripper reports.
+type retryHooks struct {
+	NoopHooks
+
+	backoffs []time.Duration
+}
+
+func (r *retryHooks) Retry(_ context.Context, event RetryEvent) {
+	r.backoffs = append(r.backoffs, event.Backoff)
+}
+
+func Test_RoundTripper_Backoff(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	hooks := &retryHooks{}
+
+	// The limiter is full for long enough that every attempt fails.
+	limiter := newRateLimiter(time.Hour, 1, clock)
+	tryTake(limiter)
+
+	transport := &roundTripper{
+		inner:      http.DefaultTransport,
+		limiter:    limiter,
+		maxRetries: 5,
+		observer:   newObserver(hooks, nil),
+		clock:      clock,
+	}
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://api.scryfall.com/cards/random", nil)
+
+	if _, err := transport.RoundTrip(req); !errors.Is(err, ErrTimeoutFromLimiter) {
+		t.Fatalf("expected ErrTimeoutFromLimiter, got %v", err)
+	}
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second}
+	if len(hooks.backoffs) != len(want) {
+		t.Fatalf("expected backoffs %v, got %v", want, hooks.backoffs)
+	}
+
+	for i := range want {
+		if hooks.backoffs[i] != want[i] {
+			t.Errorf("expected backoffs %v, got %v", want, hooks.backoffs)
+		}
+	}
+
+	if waited := clock.Now().Sub(start); waited != 31*time.Second {
+		t.Errorf("expected to back off for 31s, got %v", waited)
+	}
+}
+
+func Test_RoundTripper_Trace(t *testing.T) {
+	t.Parallel()
+
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	inner := &blockingTransport{release: make(chan struct{})}
+	close(inner.release)
+
+	// The limiter is full for longer than the first backoff.
+	limiter := newRateLimiter(2*time.Second, 1, clock)
+	tryTake(limiter)
+
+	transport := &roundTripper{inner: inner, limiter: limiter, maxRetries: 5, clock: clock}
+	trace := &requestTrace{}
+	ctx := withRequestTrace(context.Background(), trace)
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.scryfall.com/cards/random", nil)
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if trace.attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", trace.attempts)
+	}
+}
+
+// queueClock is a FakeClock that lets the requests queued ahead of
+// another take every slot that frees up while it sleeps.
+type queueClock struct {
+	*FakeClock
+
+	limiter *rateLimiter
+	ahead   []*limiterTicket
+}
+
+func (q *queueClock) Sleep(ctx context.Context, d time.Duration) error {
+	if err := q.FakeClock.Sleep(ctx, d); err != nil {
+		return err
+	}
+
+	q.limiter.lock.Lock()
+	defer q.limiter.lock.Unlock()
+
+	q.limiter.clean()
+
+	for _, ticket := range q.ahead {
+		q.limiter.take(ticket)
+	}
+
+	return nil
+}
+
+func Test_RoundTripper_Queued(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &queueClock{FakeClock: NewFakeClock(start)}
+	clock.limiter = newRateLimiter(time.Second, 1, clock)
+
+	// Far more requests are queued ahead than can be let through
+	// before the request would run out of retries.
+	for i := 0; i < 60; i++ {
+		clock.ahead = append(clock.ahead, clock.limiter.enqueue(PriorityLow))
+	}
+
+	inner := &blockingTransport{release: make(chan struct{})}
+	close(inner.release)
+
+	transport := &roundTripper{inner: inner, limiter: clock.limiter, maxRetries: 5, clock: clock}
+	ctx := WithPriority(context.Background(), PriorityLow)
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.scryfall.com/cards/random", nil)
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("expected the request to wait its turn, got %v", err)
+	}
+	defer resp.Body.Close()
+
+	if waited := clock.Now().Sub(start); waited < 60*time.Second {
+		t.Errorf("expected to wait for the requests ahead, waited %v", waited)
+	}
+}
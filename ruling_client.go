@@ -9,6 +9,7 @@ import (
 // RulingClient contains methods for interacting with rulings.
 type RulingClient struct {
 	client *http.Client
+	clock  Clock
 }
 
 // ByScryfallID fetches all of the rulings for a card by its Scryfall ID.
@@ -21,7 +22,7 @@ func (r *RulingClient) ByScryfallID(ctx context.Context, id string) ([]Ruling, e
 
 	var lst listContainer[Ruling]
 
-	err = doRequest(r.client, req, &lst)
+	err = doRequest(r.client, r.clock, req, &lst)
 	if err != nil {
 		return nil, fmt.Errorf("failed to perform HTTP request: %w", err)
 	}
@@ -38,7 +39,7 @@ func (r *RulingClient) ByMultiverseID(ctx context.Context, id int) ([]Ruling, er
 
 	var list listContainer[Ruling]
 
-	err = doRequest(r.client, req, &list)
+	err = doRequest(r.client, r.clock, req, &list)
 	if err != nil {
 		return nil, fmt.Errorf("failed to perform HTTP request: %w", err)
 	}
@@ -55,7 +56,7 @@ func (r *RulingClient) ByMTGOID(ctx context.Context, id int) ([]Ruling, error) {
 
 	var list listContainer[Ruling]
 
-	err = doRequest(r.client, req, &list)
+	err = doRequest(r.client, r.clock, req, &list)
 	if err != nil {
 		return nil, fmt.Errorf("failed to perform HTTP request: %w", err)
 	}
@@ -72,7 +73,7 @@ func (r *RulingClient) ByArenaID(ctx context.Context, id int) ([]Ruling, error)
 
 	var list listContainer[Ruling]
 
-	err = doRequest(r.client, req, &list)
+	err = doRequest(r.client, r.clock, req, &list)
 	if err != nil {
 		return nil, fmt.Errorf("failed to perform HTTP request: %w", err)
 	}
@@ -89,7 +90,7 @@ func (r *RulingClient) ByCodeAndNumber(ctx context.Context, code string, number
 
 	var list listContainer[Ruling]
 
-	err = doRequest(r.client, req, &list)
+	err = doRequest(r.client, r.clock, req, &list)
 	if err != nil {
 		return nil, fmt.Errorf("failed to perform HTTP request: %w", err)
 	}
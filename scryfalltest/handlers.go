@@ -0,0 +1,675 @@
+package scryfalltest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/SethCurry/gofall"
+)
+
+// apiBase is the base URL used in links within responses.  Clients
+// from this package send requests for it to the server.
+const apiBase = "https://api.scryfall.com"
+
+// maxCollectionIdentifiers is the most identifiers Scryfall accepts
+// in one collection request.
+const maxCollectionIdentifiers = 75
+
+// maxAutocomplete is the most names returned by autocomplete.
+const maxAutocomplete = 20
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /cards/named", s.named)
+	mux.HandleFunc("GET /cards/search", s.search)
+	mux.HandleFunc("GET /cards/autocomplete", s.autocomplete)
+	mux.HandleFunc("GET /cards/random", s.random)
+	mux.HandleFunc("POST /cards/collection", s.collection)
+	mux.HandleFunc("GET /cards/{id}", s.byID)
+	mux.HandleFunc("GET /cards/{id}/rulings", s.rulingsByID)
+	mux.HandleFunc("GET /cards/multiverse/{id}/rulings", s.rulingsByMultiverseID)
+	mux.HandleFunc("GET /cards/mtgo/{id}/rulings", s.rulingsByMTGOID)
+	mux.HandleFunc("GET /cards/{code}/{number}/rulings", s.rulingsByCodeAndNumber)
+	mux.HandleFunc("GET /bulk-data", s.bulkDataList)
+	mux.HandleFunc("GET /bulk-data/{idOrType}", s.bulkData)
+	mux.HandleFunc("GET /bulk/{file}", s.bulkDownload)
+	mux.HandleFunc("GET /sets", s.setList)
+	mux.HandleFunc("GET /sets/{code}", s.set)
+	mux.HandleFunc("GET /catalog/{name}", s.catalog)
+	mux.HandleFunc("GET /cdn/{version}/{face}/{file}", s.image)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "", "No endpoint at "+r.URL.Path+".")
+	})
+
+	return s.middleware(mux)
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+type errorBody struct {
+	Object   string   `json:"object"`
+	Code     string   `json:"code"`
+	Status   int      `json:"status"`
+	Type     string   `json:"type,omitempty"`
+	Details  string   `json:"details"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+func writeError(w http.ResponseWriter, status int, errType, details string, warnings ...string) {
+	writeJSON(w, status, errorBody{
+		Object:   "error",
+		Code:     strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_"),
+		Status:   status,
+		Type:     errType,
+		Details:  details,
+		Warnings: warnings,
+	})
+}
+
+type list[T any] struct {
+	Object     string   `json:"object"`
+	Data       []T      `json:"data"`
+	HasMore    bool     `json:"has_more"`
+	NextPage   string   `json:"next_page,omitempty"`
+	TotalCards int      `json:"total_cards,omitempty"`
+	Warnings   []string `json:"warnings,omitempty"`
+	NotFound   []any    `json:"not_found,omitempty"`
+}
+
+type catalog struct {
+	Object      string   `json:"object"`
+	URI         string   `json:"uri"`
+	TotalValues int      `json:"total_values"`
+	Data        []string `json:"data"`
+}
+
+// writeCard writes a card in the format the request asked for.
+func (s *Server) writeCard(w http.ResponseWriter, r *http.Request, card *gofall.Card) {
+	query := r.URL.Query()
+
+	switch query.Get("format") {
+	case "", "json":
+		writeJSON(w, http.StatusOK, card)
+	case "text":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = fmt.Fprint(w, cardText(card))
+	case "image":
+		version := query.Get("version")
+		if version == "" {
+			version = string(gofall.ImageTypeLarge)
+		}
+
+		face := "front"
+
+		if query.Get("face") == "back" {
+			if len(card.CardFaces) < 2 {
+				writeError(w, http.StatusUnprocessableEntity, "", card.Name+" does not have a back face.")
+
+				return
+			}
+
+			face = "back"
+		}
+
+		http.Redirect(w, r, fmt.Sprintf("https://cards.scryfall.io/cdn/%s/%s/%s.jpg", version, face, card.ID), http.StatusFound)
+	default:
+		writeError(w, http.StatusBadRequest, "", "Unknown format "+query.Get("format")+".")
+	}
+}
+
+// cardText renders a card the way format=text does.
+func cardText(card *gofall.Card) string {
+	lines := []string{strings.TrimSpace(card.Name + " " + card.ManaCost), card.TypeLine}
+
+	if card.OracleText != "" {
+		lines = append(lines, card.OracleText)
+	}
+
+	if card.Power.Valid() {
+		lines = append(lines, card.Power.String()+"/"+card.Toughness.String())
+	}
+
+	if card.Loyalty.Valid() {
+		lines = append(lines, "Loyalty: "+card.Loyalty.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// faceNames returns the card's name and the names of each of its faces.
+func faceNames(card *gofall.Card) []string {
+	names := []string{card.Name}
+
+	for _, face := range card.CardFaces {
+		names = append(names, face.Name)
+	}
+
+	return names
+}
+
+func (s *Server) named(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	set := query.Get("set")
+	exact, fuzzy := query.Get("exact"), query.Get("fuzzy")
+
+	if (exact == "") == (fuzzy == "") {
+		writeError(w, http.StatusBadRequest, "", "Provide exactly one of exact or fuzzy.")
+
+		return
+	}
+
+	var matches []*gofall.Card
+
+	for i := range s.cards {
+		card := &s.cards[i]
+
+		if set != "" && !strings.EqualFold(card.SetCode, set) {
+			continue
+		}
+
+		for _, name := range faceNames(card) {
+			if (exact != "" && strings.EqualFold(name, exact)) ||
+				(fuzzy != "" && strings.Contains(strings.ToLower(name), strings.ToLower(fuzzy))) {
+				matches = append(matches, card)
+
+				break
+			}
+		}
+	}
+
+	// A fuzzy name that exactly matches one card is not ambiguous.
+	if fuzzy != "" && len(matches) > 1 {
+		for _, card := range matches {
+			if strings.EqualFold(card.Name, fuzzy) {
+				matches = []*gofall.Card{card}
+
+				break
+			}
+		}
+	}
+
+	switch {
+	case len(matches) == 0:
+		writeError(w, http.StatusNotFound, "", "No cards found matching “"+exact+fuzzy+"”")
+	case len(matches) > 1 && !sameName(matches):
+		writeError(w, http.StatusNotFound, "ambiguous", "Too many cards match ambiguous name “"+fuzzy+"”.")
+	default:
+		s.writeCard(w, r, matches[0])
+	}
+}
+
+// sameName reports whether every card is a printing of the same card.
+func sameName(cards []*gofall.Card) bool {
+	for _, card := range cards {
+		if card.Name != cards[0].Name {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *Server) search(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	filter, warnings, err := parseQuery(query.Get("q"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "", "All of your terms were ignored.", warnings...)
+
+		return
+	}
+
+	var matches []gofall.Card
+
+	for _, card := range s.cards {
+		if filter(&card) {
+			matches = append(matches, card)
+		}
+	}
+
+	if len(matches) == 0 {
+		writeError(w, http.StatusNotFound, "", "Your query didn’t match any cards.", warnings...)
+
+		return
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Name < matches[j].Name })
+
+	page, err := strconv.Atoi(query.Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	start := min((page-1)*s.pageSize, len(matches))
+	end := min(start+s.pageSize, len(matches))
+
+	result := list[gofall.Card]{
+		Object:     "list",
+		Data:       matches[start:end],
+		HasMore:    end < len(matches),
+		TotalCards: len(matches),
+		Warnings:   warnings,
+	}
+
+	if result.HasMore {
+		next := url.Values{}
+		for key, values := range query {
+			next[key] = values
+		}
+
+		next.Set("page", strconv.Itoa(page+1))
+		result.NextPage = apiBase + "/cards/search?" + next.Encode()
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) autocomplete(w http.ResponseWriter, r *http.Request) {
+	prefix := strings.ToLower(r.URL.Query().Get("q"))
+	names := []string{}
+
+	seen := map[string]bool{}
+
+	for _, card := range s.cards {
+		if len(names) == maxAutocomplete {
+			break
+		}
+
+		if prefix != "" && strings.Contains(strings.ToLower(card.Name), prefix) && !seen[card.Name] {
+			seen[card.Name] = true
+			names = append(names, card.Name)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, catalog{Object: "catalog", TotalValues: len(names), Data: names})
+}
+
+func (s *Server) random(w http.ResponseWriter, r *http.Request) {
+	filter, _, err := parseQuery(r.URL.Query().Get("q"))
+	if err != nil {
+		// An empty query picks from every card.
+		filter = func(*gofall.Card) bool { return true }
+	}
+
+	var matches []*gofall.Card
+
+	for i := range s.cards {
+		if filter(&s.cards[i]) {
+			matches = append(matches, &s.cards[i])
+		}
+	}
+
+	if len(matches) == 0 {
+		writeError(w, http.StatusNotFound, "", "Your query didn’t match any cards.")
+
+		return
+	}
+
+	s.writeCard(w, r, matches[rand.Intn(len(matches))]) //nolint:gosec
+}
+
+func (s *Server) byID(w http.ResponseWriter, r *http.Request) {
+	card := s.find(func(card *gofall.Card) bool { return card.ID == r.PathValue("id") })
+	if card == nil {
+		writeError(w, http.StatusNotFound, "", "No card found with the given ID.")
+
+		return
+	}
+
+	s.writeCard(w, r, card)
+}
+
+func (s *Server) find(match func(*gofall.Card) bool) *gofall.Card {
+	for i := range s.cards {
+		if match(&s.cards[i]) {
+			return &s.cards[i]
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) collection(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Identifiers []gofall.CardIdentifier `json:"identifiers"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "", "Invalid JSON: "+err.Error())
+
+		return
+	}
+
+	if len(request.Identifiers) > maxCollectionIdentifiers {
+		writeError(w, http.StatusUnprocessableEntity, "", "Too many identifiers.")
+
+		return
+	}
+
+	result := list[gofall.Card]{Object: "list", Data: []gofall.Card{}}
+
+	for _, identifier := range request.Identifiers {
+		card := s.find(func(card *gofall.Card) bool { return identifies(identifier, card) })
+		if card == nil {
+			result.NotFound = append(result.NotFound, identifier)
+
+			continue
+		}
+
+		result.Data = append(result.Data, *card)
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// identifies reports whether the identifier refers to the card.
+func identifies(id gofall.CardIdentifier, card *gofall.Card) bool {
+	switch {
+	case id.ID != "":
+		return id.ID == card.ID
+	case id.MTGOID != 0:
+		return id.MTGOID == card.MTGOID
+	case id.MultiverseID != 0:
+		for _, multiverseID := range card.MultiverseIDs {
+			if multiverseID == id.MultiverseID {
+				return true
+			}
+		}
+
+		return false
+	case id.OracleID != "":
+		return id.OracleID == card.OracleID
+	case id.IllustrationID != "":
+		return id.IllustrationID == card.IllustrationID
+	case id.Name != "":
+		return strings.EqualFold(id.Name, card.Name) && (id.Set == "" || strings.EqualFold(id.Set, card.SetCode))
+	case id.Set != "" && id.CollectorNumber != "":
+		return strings.EqualFold(id.Set, card.SetCode) && id.CollectorNumber == card.CollectorNumber
+	default:
+		return false
+	}
+}
+
+func (s *Server) writeRulings(w http.ResponseWriter, card *gofall.Card) {
+	if card == nil {
+		writeError(w, http.StatusNotFound, "", "No card found with the given ID.")
+
+		return
+	}
+
+	rulings := []gofall.Ruling{}
+
+	for _, ruling := range s.rulings {
+		if ruling.OracleID == card.OracleID {
+			rulings = append(rulings, ruling)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, list[gofall.Ruling]{Object: "list", Data: rulings})
+}
+
+func (s *Server) rulingsByID(w http.ResponseWriter, r *http.Request) {
+	s.writeRulings(w, s.find(func(card *gofall.Card) bool { return card.ID == r.PathValue("id") }))
+}
+
+func (s *Server) rulingsByMultiverseID(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.Atoi(r.PathValue("id"))
+
+	s.writeRulings(w, s.find(func(card *gofall.Card) bool {
+		return identifies(gofall.CardIdentifier{MultiverseID: id}, card)
+	}))
+}
+
+func (s *Server) rulingsByMTGOID(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.Atoi(r.PathValue("id"))
+
+	s.writeRulings(w, s.find(func(card *gofall.Card) bool { return id != 0 && card.MTGOID == id }))
+}
+
+func (s *Server) rulingsByCodeAndNumber(w http.ResponseWriter, r *http.Request) {
+	s.writeRulings(w, s.find(func(card *gofall.Card) bool {
+		return strings.EqualFold(card.SetCode, r.PathValue("code")) && card.CollectorNumber == r.PathValue("number")
+	}))
+}
+
+func (s *Server) image(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "image/jpeg")
+	_, _ = fmt.Fprintf(w, "fake %s %s image of %s", r.PathValue("version"), r.PathValue("face"), r.PathValue("file"))
+}
+
+// bulkTypes are the bulk data sources the server offers, with the
+// fixed IDs it gives them.
+var bulkTypes = []struct {
+	id, bulkType string
+}{
+	{"27bf3214-1271-490b-bdfe-c0be6c23d02e", gofall.BulkDataTypeOracleCards},
+	{"6bbcf976-6369-4401-88fc-3a9e4984c305", gofall.BulkDataTypeUniqueArtwork},
+	{"e2ef41e3-5778-4bc2-af3f-78eca4dd9c23", gofall.BulkDataTypeDefaultCards},
+	{"922288cb-4bef-45e1-bb30-0c2bd3d3534f", gofall.BulkDataTypeAllCards},
+	{"4f6ff4a5-8cfd-4a09-8bd8-dd8bd9ae1ac6", gofall.BulkDataTypeRulings},
+}
+
+func (s *Server) bulkDataSource(id, bulkType string) gofall.BulkDataSource {
+	return gofall.BulkDataSource{
+		Object:          gofall.ObjectBulkData,
+		ID:              id,
+		Type:            bulkType,
+		URI:             apiBase + "/bulk-data/" + id,
+		Name:            strings.ReplaceAll(bulkType, "_", " "),
+		DownloadURI:     s.URL + "/bulk/" + bulkType + ".json",
+		ContentType:     "application/json",
+		ContentEncoding: "gzip",
+	}
+}
+
+func (s *Server) bulkDataList(w http.ResponseWriter, _ *http.Request) {
+	result := list[gofall.BulkDataSource]{Object: "list"}
+
+	for _, source := range bulkTypes {
+		result.Data = append(result.Data, s.bulkDataSource(source.id, source.bulkType))
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) bulkData(w http.ResponseWriter, r *http.Request) {
+	idOrType := r.PathValue("idOrType")
+
+	for _, source := range bulkTypes {
+		if source.id == idOrType || source.bulkType == idOrType {
+			writeJSON(w, http.StatusOK, s.bulkDataSource(source.id, source.bulkType))
+
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "", "No bulk data found for "+idOrType+".")
+}
+
+// bulkDownload serves the files bulk data sources link to.  Every card
+// source contains all of the server's cards.
+func (s *Server) bulkDownload(w http.ResponseWriter, r *http.Request) {
+	bulkType, ok := strings.CutSuffix(r.PathValue("file"), ".json")
+
+	switch {
+	case !ok:
+		writeError(w, http.StatusNotFound, "", "No such file.")
+	case bulkType == gofall.BulkDataTypeRulings:
+		writeJSON(w, http.StatusOK, append([]gofall.Ruling{}, s.rulings...))
+	default:
+		for _, source := range bulkTypes {
+			if source.bulkType == bulkType {
+				writeJSON(w, http.StatusOK, append([]gofall.Card{}, s.cards...))
+
+				return
+			}
+		}
+
+		writeError(w, http.StatusNotFound, "", "No such file.")
+	}
+}
+
+// set is a set as returned by the sets endpoints.  Only the fields that
+// can be derived from the server's cards are set.
+type set struct {
+	Object     string `json:"object"`
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	URI        string `json:"uri"`
+	SearchURI  string `json:"search_uri"`
+	CardCount  int    `json:"card_count"`
+	ReleasedAt string `json:"released_at,omitempty"`
+}
+
+// sets returns the sets of the server's cards, sorted by code.
+func (s *Server) sets() []set {
+	byCode := map[string]*set{}
+
+	for _, card := range s.cards {
+		code := strings.ToLower(card.SetCode)
+
+		if _, ok := byCode[code]; !ok {
+			byCode[code] = &set{
+				Object:    "set",
+				Code:      code,
+				Name:      card.SetName,
+				URI:       apiBase + "/sets/" + code,
+				SearchURI: apiBase + "/cards/search?q=" + url.QueryEscape("e:"+code),
+			}
+		}
+
+		byCode[code].CardCount++
+	}
+
+	sets := make([]set, 0, len(byCode))
+	for _, set := range byCode {
+		sets = append(sets, *set)
+	}
+
+	sort.Slice(sets, func(i, j int) bool { return sets[i].Code < sets[j].Code })
+
+	return sets
+}
+
+func (s *Server) setList(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, list[set]{Object: "list", Data: s.sets()})
+}
+
+func (s *Server) set(w http.ResponseWriter, r *http.Request) {
+	code := strings.ToLower(r.PathValue("code"))
+
+	for _, set := range s.sets() {
+		if set.Code == code {
+			writeJSON(w, http.StatusOK, set)
+
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "", "No set found for the given code.")
+}
+
+// catalogValues returns the values of a card that belong in a catalog,
+// and false if the server does not support the catalog.
+func catalogValues(name gofall.CatalogName, card *gofall.Card) ([]string, bool) {
+	typeLine := card.ParsedTypeLine()
+
+	// subtypes returns the subtypes of faces with the given card type.
+	subtypes := func(cardType string) []string {
+		var values []string
+
+		for _, face := range typeLine.Faces {
+			if face.HasType(cardType) {
+				values = append(values, face.Subtypes...)
+			}
+		}
+
+		return values
+	}
+
+	var values []string
+
+	switch name {
+	case gofall.CatalogCardNames:
+		values = []string{card.Name}
+	case gofall.CatalogArtistNames:
+		values = []string{card.Artist}
+	case gofall.CatalogSupertypes:
+		for _, face := range typeLine.Faces {
+			values = append(values, face.Supertypes...)
+		}
+	case gofall.CatalogCardTypes:
+		for _, face := range typeLine.Faces {
+			values = append(values, face.Types...)
+		}
+	case gofall.CatalogArtifactTypes:
+		values = subtypes("Artifact")
+	case gofall.CatalogBattleTypes:
+		values = subtypes("Battle")
+	case gofall.CatalogCreatureTypes:
+		values = append(subtypes("Creature"), subtypes("Kindred")...)
+	case gofall.CatalogEnchantmentTypes:
+		values = subtypes("Enchantment")
+	case gofall.CatalogLandTypes:
+		values = subtypes("Land")
+	case gofall.CatalogPlaneswalkerTypes:
+		values = subtypes("Planeswalker")
+	case gofall.CatalogSpellTypes:
+		values = append(subtypes("Instant"), subtypes("Sorcery")...)
+	case gofall.CatalogPowers:
+		values = []string{card.Power.String()}
+	case gofall.CatalogToughnesses:
+		values = []string{card.Toughness.String()}
+	case gofall.CatalogLoyalties:
+		values = []string{card.Loyalty.String()}
+	case gofall.CatalogKeywordAbilities:
+		values = card.Keywords
+	default:
+		return nil, false
+	}
+
+	return values, true
+}
+
+func (s *Server) catalog(w http.ResponseWriter, r *http.Request) {
+	name := gofall.CatalogName(r.PathValue("name"))
+	seen := map[string]bool{}
+	values := []string{}
+
+	if _, ok := catalogValues(name, &gofall.Card{}); !ok {
+		writeError(w, http.StatusNotFound, "", "No catalog named "+string(name)+".")
+
+		return
+	}
+
+	for i := range s.cards {
+		cardValues, _ := catalogValues(name, &s.cards[i])
+
+		for _, value := range cardValues {
+			if value != "" && !seen[value] {
+				seen[value] = true
+				values = append(values, value)
+			}
+		}
+	}
+
+	sort.Strings(values)
+
+	writeJSON(w, http.StatusOK, catalog{
+		Object:      "catalog",
+		URI:         apiBase + "/catalog/" + string(name),
+		TotalValues: len(values),
+		Data:        values,
+	})
+}
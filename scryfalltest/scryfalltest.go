@@ -0,0 +1,228 @@
+// Package scryfalltest provides an in-process fake of the Scryfall API for
+// testing code that uses gofall without hitting the live API, in the style
+// of net/http/httptest.
+//
+// The fake serves the card, ruling, bulk data, set and catalog endpoints
+// from a fixed set of cards and rulings.  Searches support a small subset
+// of Scryfall's syntax; see Server.
+package scryfalltest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/SethCurry/gofall"
+)
+
+// Options configures a Server.
+type Options struct {
+	// Cards are the cards the server knows about.  LoadCards can read
+	// them from a bulk data file such as test/cards.json.
+	Cards []gofall.Card
+
+	// Rulings are the rulings the server knows about, matched to
+	// cards by Oracle ID.
+	Rulings []gofall.Ruling
+
+	// PageSize is the number of cards per page of search results.
+	// Defaults to 175, the same as Scryfall.
+	PageSize int
+
+	// Latency is added to every response.  It can be changed
+	// later with SetLatency.
+	Latency time.Duration
+}
+
+// NewServer starts a fake Scryfall server.  The caller must call Close
+// when finished with it.
+func NewServer(opts Options) *Server {
+	defaultPageSize := 175
+
+	if opts.PageSize <= 0 {
+		opts.PageSize = defaultPageSize
+	}
+
+	s := &Server{
+		cards:    opts.Cards,
+		rulings:  opts.Rulings,
+		pageSize: opts.PageSize,
+		latency:  opts.Latency,
+	}
+
+	s.server = httptest.NewServer(s.handler())
+	s.URL = s.server.URL
+
+	return s
+}
+
+// Server is a fake Scryfall server.  Requests to it must be made with
+// the transport from Transport, or a client from Client, as gofall always
+// requests api.scryfall.com.
+//
+// Searches support set (e:, s:, set:), type (t:, type:) and Oracle text
+// (o:, oracle:) terms, and bare words matched against card names.  Terms
+// may be quoted and must all match.  Other terms are ignored with a
+// warning, and a search with no supported terms fails, as on Scryfall.
+// Results are sorted by name.
+type Server struct {
+	// URL is the base URL of the server, e.g. http://127.0.0.1:1234.
+	URL string
+
+	server   *httptest.Server
+	cards    []gofall.Card
+	rulings  []gofall.Ruling
+	pageSize int
+
+	lock     sync.Mutex
+	latency  time.Duration
+	failures []int
+	requests int
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Transport returns an http.RoundTripper that sends every request to the
+// server, whatever host it was made for.  Use it as the Transport of the
+// HTTPClient in gofall.ClientOptions to combine the fake with other options.
+func (s *Server) Transport() http.RoundTripper {
+	target, _ := url.Parse(s.URL)
+
+	return &rewriteTransport{target: target, inner: s.server.Client().Transport}
+}
+
+// Client returns a gofall.Client that sends its requests to the server.
+func (s *Server) Client() *gofall.Client {
+	return gofall.NewClient(&http.Client{Transport: s.Transport()})
+}
+
+// SetLatency changes how long the server waits before every response.
+func (s *Server) SetLatency(latency time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.latency = latency
+}
+
+// FailNext makes the next n requests fail with the given status,
+// e.g. http.StatusServiceUnavailable.
+func (s *Server) FailNext(n int, status int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for i := 0; i < n; i++ {
+		s.failures = append(s.failures, status)
+	}
+}
+
+// RateLimitNext makes the next n requests fail with a 429, as Scryfall
+// does when its rate limit is exceeded.
+func (s *Server) RateLimitNext(n int) {
+	s.FailNext(n, http.StatusTooManyRequests)
+}
+
+// Requests returns the number of requests the server has received.
+func (s *Server) Requests() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.requests
+}
+
+// LoadCards reads cards from a JSON file in the format of Scryfall's
+// bulk data, such as the test/cards.json fixture.
+func LoadCards(path string) ([]gofall.Card, error) {
+	return loadBulk[gofall.Card](path)
+}
+
+// LoadRulings reads rulings from a JSON file in the format of
+// Scryfall's rulings bulk data.
+func LoadRulings(path string) ([]gofall.Ruling, error) {
+	return loadBulk[gofall.Ruling](path)
+}
+
+func loadBulk[T any](path string) ([]T, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer fd.Close()
+
+	reader, err := gofall.NewBulkReader[T](fd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var items []T
+
+	for {
+		item, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return items, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		items = append(items, *item)
+	}
+}
+
+// rewriteTransport sends every request to the server.
+type rewriteTransport struct {
+	target *url.URL
+	inner  http.RoundTripper
+}
+
+func (r *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = r.target.Scheme
+	req.URL.Host = r.target.Host
+	req.Host = ""
+
+	return r.inner.RoundTrip(req)
+}
+
+// middleware counts requests and applies injected latency and failures.
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.lock.Lock()
+
+		s.requests++
+		latency := s.latency
+
+		failure := 0
+		if len(s.failures) > 0 {
+			failure = s.failures[0]
+			s.failures = s.failures[1:]
+		}
+
+		s.lock.Unlock()
+
+		if latency > 0 {
+			select {
+			case <-time.After(latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		if failure != 0 {
+			writeError(w, failure, "", fmt.Sprintf("Injected %d error.", failure))
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
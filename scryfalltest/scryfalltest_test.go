@@ -0,0 +1,328 @@
+package scryfalltest_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/SethCurry/gofall"
+	"github.com/SethCurry/gofall/scryfalltest"
+)
+
+func newServer(t *testing.T, opts scryfalltest.Options) *scryfalltest.Server {
+	t.Helper()
+
+	cards, err := scryfalltest.LoadCards("../test/cards.json")
+	if err != nil {
+		t.Fatalf("failed to load cards: %v", err)
+	}
+
+	rulings, err := scryfalltest.LoadRulings("../test/rulings.json")
+	if err != nil {
+		t.Fatalf("failed to load rulings: %v", err)
+	}
+
+	opts.Cards = cards
+	opts.Rulings = rulings
+
+	server := scryfalltest.NewServer(opts)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func Test_Server_Named(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := newServer(t, scryfalltest.Options{}).Client()
+
+	card, err := client.Card.Named(ctx, gofall.CardNamedRequest{Exact: "fury sliver"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if card.Name != "Fury Sliver" || card.Power.String() != "3" {
+		t.Errorf("unexpected card %q with power %q", card.Name, card.Power)
+	}
+
+	card, err = client.Card.Named(ctx, gofall.CardNamedRequest{Fuzzy: "Desperate Parry"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if card.Name != "Obyra's Attendants // Desperate Parry" {
+		t.Errorf("expected a match on a face name, got %q", card.Name)
+	}
+
+	if _, err := client.Card.Named(ctx, gofall.CardNamedRequest{Exact: "Black Lotus"}); !errors.Is(err, gofall.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	_, err = client.Card.Named(ctx, gofall.CardNamedRequest{Fuzzy: "s"})
+	if !errors.Is(err, gofall.ErrAmbiguous) {
+		t.Fatalf("expected ErrAmbiguous, got %v", err)
+	}
+
+	var ambiguous *gofall.AmbiguousNameError
+	if !errors.As(err, &ambiguous) || len(ambiguous.Candidates) == 0 {
+		t.Errorf("expected candidates for an ambiguous name, got %v", err)
+	}
+}
+
+func Test_Server_Search(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := newServer(t, scryfalltest.Options{PageSize: 2})
+	client := server.Client()
+
+	pager, err := client.Card.Search(ctx, "t:creature", gofall.CardSearchOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var (
+		names []string
+		pages int
+	)
+
+	for {
+		cards, err := pager.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		pages++
+
+		for _, card := range cards {
+			if !card.ParsedTypeLine().IsCreature() {
+				t.Errorf("expected only creatures, got %q", card.TypeLine)
+			}
+
+			names = append(names, card.Name)
+		}
+	}
+
+	if pages < 2 {
+		t.Errorf("expected several pages, got %d", pages)
+	}
+
+	for i := 1; i < len(names); i++ {
+		if names[i-1] > names[i] {
+			t.Errorf("expected results sorted by name, got %v", names)
+		}
+	}
+
+	pager, _ = client.Card.Search(ctx, "e:tsp sliver", gofall.CardSearchOptions{})
+
+	cards, err := pager.Next(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(cards) != 1 || cards[0].Name != "Fury Sliver" {
+		t.Errorf("expected only Fury Sliver, got %v", cards)
+	}
+
+	pager, _ = client.Card.Search(ctx, "usd>100", gofall.CardSearchOptions{})
+	if _, err := pager.Next(ctx); !errors.Is(err, gofall.ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest for unsupported terms, got %v", err)
+	}
+
+	pager, _ = client.Card.Search(ctx, "black lotus", gofall.CardSearchOptions{})
+	if _, err := pager.Next(ctx); !errors.Is(err, gofall.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for no matches, got %v", err)
+	}
+}
+
+func Test_Server_Failures(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := newServer(t, scryfalltest.Options{})
+	client := server.Client()
+
+	server.RateLimitNext(1)
+	server.FailNext(1, http.StatusServiceUnavailable)
+
+	if _, err := client.Card.ByID(ctx, "0000579f-7b35-4ed3-b44c-db2a538066fe"); !errors.Is(err, gofall.ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+	}
+
+	if _, err := client.Card.ByID(ctx, "0000579f-7b35-4ed3-b44c-db2a538066fe"); !errors.Is(err, gofall.ErrServerError) {
+		t.Errorf("expected ErrServerError, got %v", err)
+	}
+
+	if _, err := client.Card.ByID(ctx, "0000579f-7b35-4ed3-b44c-db2a538066fe"); err != nil {
+		t.Errorf("expected the injected failures to be used up, got %v", err)
+	}
+
+	if server.Requests() != 3 {
+		t.Errorf("expected 3 requests, got %d", server.Requests())
+	}
+}
+
+func Test_Server_Latency(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t, scryfalltest.Options{Latency: time.Second})
+	client := server.Client()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.Card.Named(ctx, gofall.CardNamedRequest{Exact: "Web"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	server.SetLatency(0)
+
+	if _, err := client.Card.Named(context.Background(), gofall.CardNamedRequest{Exact: "Web"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func Test_Server_Collection(t *testing.T) {
+	t.Parallel()
+
+	client := newServer(t, scryfalltest.Options{}).Client()
+
+	cards, err := client.Card.Collection(context.Background(), []gofall.CardIdentifier{
+		{ID: "0000579f-7b35-4ed3-b44c-db2a538066fe"},
+		{Name: "Web"},
+		{Name: "Black Lotus"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(cards) != 2 || cards[0].Name != "Fury Sliver" || cards[1].Name != "Web" {
+		t.Errorf("unexpected cards %v", cards)
+	}
+}
+
+func Test_Server_Rulings(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := newServer(t, scryfalltest.Options{})
+	client := server.Client()
+
+	cards, err := scryfalltest.LoadCards("../test/cards.json")
+	if err != nil {
+		t.Fatalf("failed to load cards: %v", err)
+	}
+
+	rulings, err := scryfalltest.LoadRulings("../test/rulings.json")
+	if err != nil {
+		t.Fatalf("failed to load rulings: %v", err)
+	}
+
+	want := map[string]int{}
+	for _, ruling := range rulings {
+		want[ruling.OracleID]++
+	}
+
+	for _, card := range cards {
+		got, err := client.Rulings.ByScryfallID(ctx, card.ID)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", card.Name, err)
+		}
+
+		if len(got) != want[card.OracleID] {
+			t.Errorf("expected %d rulings for %s, got %d", want[card.OracleID], card.Name, len(got))
+		}
+	}
+}
+
+func Test_Server_BulkData(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := newServer(t, scryfalltest.Options{})
+	client := server.Client()
+
+	sources, err := client.BulkData.ListSources(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if sources.OracleCards == nil || sources.Rulings == nil {
+		t.Fatalf("expected oracle cards and rulings sources, got %+v", sources)
+	}
+
+	source, err := client.BulkData.ByType(ctx, gofall.BulkDataTypeOracleCards)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, source.DownloadURI, nil)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to download bulk data: %v", err)
+	}
+	defer resp.Body.Close()
+
+	reader, err := gofall.NewBulkReader[gofall.Card](resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read bulk data: %v", err)
+	}
+
+	count := 0
+
+	for {
+		_, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf("failed to read bulk data: %v", err)
+		}
+
+		count++
+	}
+
+	if count != 10 {
+		t.Errorf("expected 10 cards, got %d", count)
+	}
+}
+
+func Test_Server_Catalog(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := newServer(t, scryfalltest.Options{}).Client()
+
+	names, err := client.Catalog.Get(ctx, gofall.CatalogCardNames)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(names) != 10 {
+		t.Errorf("expected 10 card names, got %v", names)
+	}
+
+	types, err := client.Catalog.TypeCatalog(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fury := gofall.ParseTypeLine("Creature — Sliver")
+	if err := fury.Validate(types); err != nil {
+		t.Errorf("expected a valid type line, got %v", err)
+	}
+
+	if _, err := client.Catalog.Get(ctx, "no-such-catalog"); !errors.Is(err, gofall.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
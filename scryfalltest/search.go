@@ -0,0 +1,116 @@
+package scryfalltest
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/SethCurry/gofall"
+)
+
+// errNoTerms is returned by parseQuery when a query has no terms the
+// server understands.
+var errNoTerms = errors.New("all of the terms were ignored")
+
+// parseQuery parses a search query into a filter.  Only a subset of
+// Scryfall's syntax is supported: set (e:, s:, set:), type (t:, type:)
+// and oracle text (o:, oracle:) terms, and bare words matched against
+// the card's name.  Terms may be quoted and are ANDed together.  Other
+// terms, including comparisons, are ignored with a warning like
+// Scryfall gives.
+func parseQuery(query string) (func(*gofall.Card) bool, []string, error) {
+	var (
+		filters  []func(*gofall.Card) bool
+		warnings []string
+	)
+
+	for _, term := range splitTerms(query) {
+		key, value, found := strings.Cut(term, ":")
+		if !found {
+			key, value = "", term
+		}
+
+		// Comparisons such as pow>=3 are not supported.
+		if strings.ContainsAny(key, "<>=") || (!found && strings.ContainsAny(term, "<>=")) {
+			key = term
+		}
+
+		value = strings.ToLower(strings.Trim(value, `"`))
+
+		switch strings.ToLower(key) {
+		case "":
+			filters = append(filters, func(card *gofall.Card) bool {
+				return strings.Contains(strings.ToLower(card.Name), value)
+			})
+		case "e", "s", "set":
+			filters = append(filters, func(card *gofall.Card) bool {
+				return strings.ToLower(card.SetCode) == value
+			})
+		case "t", "type":
+			filters = append(filters, func(card *gofall.Card) bool {
+				return strings.Contains(strings.ToLower(card.TypeLine), value)
+			})
+		case "o", "oracle":
+			filters = append(filters, func(card *gofall.Card) bool {
+				return strings.Contains(strings.ToLower(oracleText(card)), value)
+			})
+		default:
+			warnings = append(warnings, "Invalid expression “"+term+"” was ignored.")
+		}
+	}
+
+	if len(filters) == 0 {
+		return nil, warnings, errNoTerms
+	}
+
+	return func(card *gofall.Card) bool {
+		for _, filter := range filters {
+			if !filter(card) {
+				return false
+			}
+		}
+
+		return true
+	}, warnings, nil
+}
+
+// splitTerms splits a query on spaces outside of double quotes.
+func splitTerms(query string) []string {
+	var (
+		terms   []string
+		current strings.Builder
+		quoted  bool
+	)
+
+	for _, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+
+			current.WriteRune(r)
+		case r == ' ' && !quoted:
+			if current.Len() > 0 {
+				terms = append(terms, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	if current.Len() > 0 {
+		terms = append(terms, current.String())
+	}
+
+	return terms
+}
+
+// oracleText returns the Oracle text of the card and all of its faces.
+func oracleText(card *gofall.Card) string {
+	text := []string{card.OracleText}
+
+	for _, face := range card.CardFaces {
+		text = append(text, face.OracleText)
+	}
+
+	return strings.Join(text, "\n")
+}
@@ -0,0 +1,214 @@
+package gofall
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// statPattern matches a power, toughness or loyalty made of an optional
+// number and an optional variable part, e.g. "3", "*", "1+*", "7-*" or "X".
+var statPattern = regexp.MustCompile(`^([+-]?(?:\d+(?:\.\d+)?|\.\d+))?(?:([+-]?)(\*²|\*|X|\?))?$`)
+
+// Stat is a card's power, toughness or loyalty.  Scryfall reports these
+// as strings because some cards have values like "*", "1+*", "X", "∞" or
+// "?".  A Stat splits them into a numeric base and a variable part, while
+// keeping the original string so it round-trips exactly.
+//
+// The zero value is a null Stat, which is what cards without the
+// characteristic have, e.g. the power of an instant.
+type Stat struct {
+	raw         string
+	base        float64
+	variable    string
+	varNegative bool
+	infinite    bool
+	valid       bool
+}
+
+// ParseStat parses a power, toughness or loyalty.  It never fails:
+// values it does not understand, such as "1d4+1", are treated as
+// entirely variable.  An empty string parses to a null Stat.
+func ParseStat(txt string) Stat {
+	if txt == "" {
+		return Stat{}
+	}
+
+	stat := Stat{raw: txt, valid: true}
+
+	if txt == "∞" {
+		stat.infinite = true
+
+		return stat
+	}
+
+	match := statPattern.FindStringSubmatch(strings.ReplaceAll(txt, "½", ".5"))
+	if match == nil || (match[1] == "" && match[3] == "") {
+		stat.variable = txt
+
+		return stat
+	}
+
+	if match[1] != "" {
+		// The pattern only matches valid floats.
+		stat.base, _ = strconv.ParseFloat(match[1], 64)
+	}
+
+	stat.variable = match[3]
+	stat.varNegative = match[2] == "-"
+
+	return stat
+}
+
+// Valid returns false if the Stat is null.
+func (s Stat) Valid() bool {
+	return s.valid
+}
+
+// Value returns the numeric part of the Stat, counting variable parts
+// as 0 the way Scryfall does when sorting.  So "1+*" is 1 and "*" is 0.
+// It returns +Inf for "∞" and 0 for a null Stat.
+func (s Stat) Value() float64 {
+	if s.infinite {
+		return math.Inf(1)
+	}
+
+	return s.base
+}
+
+// IsVariable reports whether the Stat has a variable part, e.g. "*" or "X".
+func (s Stat) IsVariable() bool {
+	return s.variable != ""
+}
+
+// Variable returns the variable part of the Stat, e.g. "*" for "1+*",
+// or an empty string if there is none.
+func (s Stat) Variable() string {
+	return s.variable
+}
+
+// VariableNegative reports whether the variable part is subtracted
+// from the base, as in "7-*".
+func (s Stat) VariableNegative() bool {
+	return s.varNegative
+}
+
+// IsInfinite reports whether the Stat is "∞".
+func (s Stat) IsInfinite() bool {
+	return s.infinite
+}
+
+// Int returns the Stat as an int if it is a whole number with no
+// variable part.
+func (s Stat) Int() (int, bool) {
+	if !s.valid || s.infinite || s.IsVariable() || s.base != math.Trunc(s.base) {
+		return 0, false
+	}
+
+	return int(s.base), true
+}
+
+// Compare returns -1, 0 or 1 if s sorts before, equal to or after other.
+// The order matches OrderPower and OrderToughness: null values first,
+// then by Value, with fixed values before variable ones of the same Value.
+func (s Stat) Compare(other Stat) int {
+	switch {
+	case s.valid != other.valid:
+		if !s.valid {
+			return -1
+		}
+
+		return 1
+	case s.Value() != other.Value():
+		if s.Value() < other.Value() {
+			return -1
+		}
+
+		return 1
+	case s.IsVariable() != other.IsVariable():
+		if !s.IsVariable() {
+			return -1
+		}
+
+		return 1
+	default:
+		return strings.Compare(s.raw, other.raw)
+	}
+}
+
+// Less reports whether s sorts before other.  See Compare.
+func (s Stat) Less(other Stat) bool {
+	return s.Compare(other) < 0
+}
+
+// String returns the Stat exactly as it was parsed, or an
+// empty string for a null Stat.
+func (s Stat) String() string {
+	return s.raw
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *Stat) UnmarshalText(txt []byte) error {
+	*s = ParseStat(string(txt))
+
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It accepts a string or null.
+func (s *Stat) UnmarshalJSON(txt []byte) error {
+	var unmarshed *string
+
+	err := json.Unmarshal(txt, &unmarshed)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal stat: %w", err)
+	}
+
+	if unmarshed == nil {
+		*s = Stat{}
+
+		return nil
+	}
+
+	return s.UnmarshalText([]byte(*unmarshed))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s Stat) MarshalText() ([]byte, error) {
+	return []byte(s.raw), nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Null stats are marshalled as null.
+func (s Stat) MarshalJSON() ([]byte, error) {
+	if !s.valid {
+		return []byte("null"), nil
+	}
+
+	marshalled, err := json.Marshal(s.raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal stat: %w", err)
+	}
+
+	return marshalled, nil
+}
+
+// SortByPower sorts cards by power the same way as OrderPower.
+// Cards with equal power keep their relative order.
+func SortByPower(cards []Card) {
+	sort.SliceStable(cards, func(i, j int) bool {
+		return cards[i].Power.Less(cards[j].Power)
+	})
+}
+
+// SortByToughness sorts cards by toughness the same way as OrderToughness.
+// Cards with equal toughness keep their relative order.
+func SortByToughness(cards []Card) {
+	sort.SliceStable(cards, func(i, j int) bool {
+		return cards[i].Toughness.Less(cards[j].Toughness)
+	})
+}
@@ -0,0 +1,115 @@
+package gofall_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/SethCurry/gofall"
+)
+
+func Test_ParseStat(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		txt      string
+		value    float64
+		variable string
+		negative bool
+	}{
+		{txt: "3", value: 3},
+		{txt: "-1", value: -1},
+		{txt: "+2", value: 2},
+		{txt: "*", variable: "*"},
+		{txt: "1+*", value: 1, variable: "*"},
+		{txt: "7-*", value: 7, variable: "*", negative: true},
+		{txt: "*²", variable: "*²"},
+		{txt: "X", variable: "X"},
+		{txt: "?", variable: "?"},
+		{txt: "½", value: 0.5},
+		{txt: "3.5", value: 3.5},
+		{txt: "1d4+1", variable: "1d4+1"},
+	}
+
+	for _, v := range testCases {
+		stat := gofall.ParseStat(v.txt)
+
+		if !stat.Valid() {
+			t.Errorf("expected %q to be valid", v.txt)
+		}
+
+		if stat.Value() != v.value || stat.Variable() != v.variable || stat.VariableNegative() != v.negative {
+			t.Errorf("unexpected parse of %q: %v %q %v", v.txt, stat.Value(), stat.Variable(), stat.VariableNegative())
+		}
+
+		if stat.String() != v.txt {
+			t.Errorf("expected %q to round trip, got %q", v.txt, stat.String())
+		}
+	}
+
+	if infinite := gofall.ParseStat("∞"); !infinite.IsInfinite() || !math.IsInf(infinite.Value(), 1) {
+		t.Errorf("expected ∞ to be infinite")
+	}
+
+	if gofall.ParseStat("").Valid() {
+		t.Errorf("expected an empty string to be null")
+	}
+
+	if n, ok := gofall.ParseStat("4").Int(); !ok || n != 4 {
+		t.Errorf("expected 4 to be an int, got %d, %v", n, ok)
+	}
+
+	if _, ok := gofall.ParseStat("1+*").Int(); ok {
+		t.Errorf("did not expect 1+* to be an int")
+	}
+}
+
+func Test_Stat_Sort(t *testing.T) {
+	t.Parallel()
+
+	cards := []gofall.Card{
+		{Name: "inf", Power: gofall.ParseStat("∞")},
+		{Name: "two", Power: gofall.ParseStat("2")},
+		{Name: "one plus star", Power: gofall.ParseStat("1+*")},
+		{Name: "null"},
+		{Name: "star", Power: gofall.ParseStat("*")},
+		{Name: "one", Power: gofall.ParseStat("1")},
+		{Name: "zero", Power: gofall.ParseStat("0")},
+	}
+
+	gofall.SortByPower(cards)
+
+	expected := []string{"null", "zero", "star", "one", "one plus star", "two", "inf"}
+
+	for i, name := range expected {
+		if cards[i].Name != name {
+			t.Errorf("expected %q at position %d, got %q", name, i, cards[i].Name)
+		}
+	}
+}
+
+func Test_Stat_JSON(t *testing.T) {
+	t.Parallel()
+
+	var card gofall.Card
+
+	if err := json.Unmarshal([]byte(`{"power":"1+*","toughness":"X","loyalty":null}`), &card); err != nil {
+		t.Fatalf("failed to unmarshal card: %v", err)
+	}
+
+	if card.Power.String() != "1+*" || card.Toughness.Variable() != "X" || card.Loyalty.Valid() {
+		t.Errorf("unexpected stats: %v %v %v", card.Power, card.Toughness, card.Loyalty)
+	}
+
+	marshalled, err := json.Marshal(struct {
+		Power   gofall.Stat `json:"power"`
+		Loyalty gofall.Stat `json:"loyalty"`
+	}{card.Power, card.Loyalty})
+	if err != nil {
+		t.Fatalf("failed to marshal stats: %v", err)
+	}
+
+	if string(marshalled) != `{"power":"1+*","loyalty":null}` {
+		t.Errorf("unexpected JSON: %s", marshalled)
+	}
+}
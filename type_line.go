@@ -0,0 +1,286 @@
+package gofall
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownType is returned by TypeLine.Validate for a supertype, card type
+// or subtype that is not in the catalog.
+var ErrUnknownType = errors.New("unknown type")
+
+// knownSupertypes are used by ParseTypeLine to tell supertypes apart from
+// card types.  Any other word before the dash is treated as a card type.
+var knownSupertypes = map[string]bool{
+	"Basic":     true,
+	"Elite":     true,
+	"Host":      true,
+	"Legendary": true,
+	"Ongoing":   true,
+	"Snow":      true,
+	"World":     true,
+}
+
+// multiWordSubtypes are subtypes that contain a space, which would
+// otherwise be split into separate subtypes.
+var multiWordSubtypes = []string{"Time Lord"}
+
+// typeLineDashes are the separators between types and subtypes.  Scryfall
+// uses an em dash, but hand-written type lines often use a hyphen.
+var typeLineDashes = []string{"—", " - "}
+
+// TypeLineFace is the type line of a single face of a card, e.g.
+// "Legendary Creature — Elf Druid".
+type TypeLineFace struct {
+	// Supertypes such as Legendary, Basic or Snow.
+	Supertypes []string
+
+	// Types are the card types such as Creature or Instant.  Words like
+	// Token that are not supertypes are also included here.
+	Types []string
+
+	// Subtypes such as Elf or Equipment.
+	Subtypes []string
+}
+
+// TypeLine is a card's type line split into supertypes, types and
+// subtypes.  Multi-faced cards have one face per side of the " // ".
+type TypeLine struct {
+	Faces []TypeLineFace
+}
+
+// ParseTypeLine parses a type line such as "Legendary Creature — Elf Druid"
+// or "Instant — Adventure // Creature — Human Knight".  It never fails;
+// use Validate to check the types against Scryfall's catalogs.
+func ParseTypeLine(typeLine string) TypeLine {
+	var ret TypeLine
+
+	if strings.TrimSpace(typeLine) == "" {
+		return ret
+	}
+
+	for _, face := range strings.Split(typeLine, "//") {
+		ret.Faces = append(ret.Faces, parseTypeLineFace(face))
+	}
+
+	return ret
+}
+
+func parseTypeLineFace(face string) TypeLineFace {
+	var ret TypeLineFace
+
+	types, subtypes := face, ""
+
+	for _, dash := range typeLineDashes {
+		if before, after, found := strings.Cut(face, dash); found {
+			types, subtypes = before, after
+
+			break
+		}
+	}
+
+	for _, word := range strings.Fields(types) {
+		if knownSupertypes[word] {
+			ret.Supertypes = append(ret.Supertypes, word)
+		} else {
+			ret.Types = append(ret.Types, word)
+		}
+	}
+
+	ret.Subtypes = splitSubtypes(subtypes)
+
+	return ret
+}
+
+// splitSubtypes splits the part of a type line after the dash, keeping
+// multi-word subtypes such as "Time Lord" together.
+func splitSubtypes(subtypes string) []string {
+	words := strings.Fields(subtypes)
+
+	var ret []string
+
+	for i := 0; i < len(words); i++ {
+		matched := false
+
+		for _, multi := range multiWordSubtypes {
+			parts := strings.Fields(multi)
+			if i+len(parts) <= len(words) && strings.Join(words[i:i+len(parts)], " ") == multi {
+				ret = append(ret, multi)
+				i += len(parts) - 1
+				matched = true
+
+				break
+			}
+		}
+
+		if !matched {
+			ret = append(ret, words[i])
+		}
+	}
+
+	return ret
+}
+
+// ParsedTypeLine parses the card's TypeLine.
+func (c *Card) ParsedTypeLine() TypeLine {
+	return ParseTypeLine(c.TypeLine)
+}
+
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasSupertype reports whether the face has the supertype, e.g. "Legendary".
+func (t TypeLineFace) HasSupertype(supertype string) bool {
+	return containsString(t.Supertypes, supertype)
+}
+
+// HasType reports whether the face has the card type, e.g. "Creature".
+func (t TypeLineFace) HasType(cardType string) bool {
+	return containsString(t.Types, cardType)
+}
+
+// HasSubtype reports whether the face has the subtype, e.g. "Elf".
+func (t TypeLineFace) HasSubtype(subtype string) bool {
+	return containsString(t.Subtypes, subtype)
+}
+
+// IsCreature reports whether the face is a creature.
+func (t TypeLineFace) IsCreature() bool {
+	return t.HasType("Creature")
+}
+
+// IsLand reports whether the face is a land.
+func (t TypeLineFace) IsLand() bool {
+	return t.HasType("Land")
+}
+
+// IsLegendary reports whether the face is legendary.
+func (t TypeLineFace) IsLegendary() bool {
+	return t.HasSupertype("Legendary")
+}
+
+// IsBasic reports whether the face is basic, e.g. a basic land.
+func (t TypeLineFace) IsBasic() bool {
+	return t.HasSupertype("Basic")
+}
+
+// String formats the face the way Scryfall does, with an em dash
+// before the subtypes.
+func (t TypeLineFace) String() string {
+	ret := strings.Join(append(append([]string{}, t.Supertypes...), t.Types...), " ")
+
+	if len(t.Subtypes) > 0 {
+		ret += " — " + strings.Join(t.Subtypes, " ")
+	}
+
+	return ret
+}
+
+// Front returns the front face, or an empty face if there are none.
+func (t TypeLine) Front() TypeLineFace {
+	if len(t.Faces) == 0 {
+		return TypeLineFace{}
+	}
+
+	return t.Faces[0]
+}
+
+// anyFace reports whether any face matches.
+func (t TypeLine) anyFace(match func(TypeLineFace) bool) bool {
+	for _, face := range t.Faces {
+		if match(face) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasSupertype reports whether any face has the supertype.
+func (t TypeLine) HasSupertype(supertype string) bool {
+	return t.anyFace(func(f TypeLineFace) bool { return f.HasSupertype(supertype) })
+}
+
+// HasType reports whether any face has the card type.
+func (t TypeLine) HasType(cardType string) bool {
+	return t.anyFace(func(f TypeLineFace) bool { return f.HasType(cardType) })
+}
+
+// HasSubtype reports whether any face has the subtype.
+func (t TypeLine) HasSubtype(subtype string) bool {
+	return t.anyFace(func(f TypeLineFace) bool { return f.HasSubtype(subtype) })
+}
+
+// IsCreature reports whether any face is a creature.  Use Front
+// to only check the front face.
+func (t TypeLine) IsCreature() bool {
+	return t.anyFace(TypeLineFace.IsCreature)
+}
+
+// IsLand reports whether any face is a land.  Use Front to only
+// check the front face, e.g. for modal double-faced spells.
+func (t TypeLine) IsLand() bool {
+	return t.anyFace(TypeLineFace.IsLand)
+}
+
+// IsLegendary reports whether any face is legendary.
+func (t TypeLine) IsLegendary() bool {
+	return t.anyFace(TypeLineFace.IsLegendary)
+}
+
+// String formats the type line the way Scryfall does.
+func (t TypeLine) String() string {
+	faces := make([]string, 0, len(t.Faces))
+
+	for _, face := range t.Faces {
+		faces = append(faces, face.String())
+	}
+
+	return strings.Join(faces, " // ")
+}
+
+// TypeCatalog lists the known types to validate a TypeLine against.
+// It can be fetched with CatalogClient.TypeCatalog.
+type TypeCatalog struct {
+	Supertypes []string
+	CardTypes  []string
+
+	// Subtypes contains every kind of subtype: creature types,
+	// land types, artifact types and so on.
+	Subtypes []string
+}
+
+// Validate checks every type in the type line against the catalog.  It
+// returns an error wrapping ErrUnknownType for each unknown type.  Lists
+// in the catalog that are empty are not checked.
+func (t TypeLine) Validate(catalog *TypeCatalog) error {
+	var errs []error
+
+	check := func(kind string, values, known []string) {
+		if len(known) == 0 {
+			return
+		}
+
+		for _, value := range values {
+			if !containsString(known, value) {
+				errs = append(errs, fmt.Errorf("%w: %s %q", ErrUnknownType, kind, value))
+			}
+		}
+	}
+
+	for _, face := range t.Faces {
+		check("supertype", face.Supertypes, catalog.Supertypes)
+		check("card type", face.Types, catalog.CardTypes)
+		check("subtype", face.Subtypes, catalog.Subtypes)
+	}
+
+	return errors.Join(errs...)
+}
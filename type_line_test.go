@@ -0,0 +1,122 @@
+package gofall_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/SethCurry/gofall"
+)
+
+func Test_ParseTypeLine(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		typeLine string
+		expected []gofall.TypeLineFace
+	}{
+		{
+			typeLine: "Legendary Creature — Elf Druid",
+			expected: []gofall.TypeLineFace{
+				{Supertypes: []string{"Legendary"}, Types: []string{"Creature"}, Subtypes: []string{"Elf", "Druid"}},
+			},
+		},
+		{
+			typeLine: "Instant",
+			expected: []gofall.TypeLineFace{{Types: []string{"Instant"}}},
+		},
+		{
+			typeLine: "Basic Snow Land — Forest",
+			expected: []gofall.TypeLineFace{
+				{Supertypes: []string{"Basic", "Snow"}, Types: []string{"Land"}, Subtypes: []string{"Forest"}},
+			},
+		},
+		{
+			typeLine: "Sorcery — Adventure // Creature — Human Knight",
+			expected: []gofall.TypeLineFace{
+				{Types: []string{"Sorcery"}, Subtypes: []string{"Adventure"}},
+				{Types: []string{"Creature"}, Subtypes: []string{"Human", "Knight"}},
+			},
+		},
+		{
+			typeLine: "Legendary Creature — Time Lord Doctor",
+			expected: []gofall.TypeLineFace{
+				{Supertypes: []string{"Legendary"}, Types: []string{"Creature"}, Subtypes: []string{"Time Lord", "Doctor"}},
+			},
+		},
+		{
+			typeLine: "Artifact Creature - Golem",
+			expected: []gofall.TypeLineFace{
+				{Types: []string{"Artifact", "Creature"}, Subtypes: []string{"Golem"}},
+			},
+		},
+		{
+			typeLine: "",
+			expected: nil,
+		},
+	}
+
+	for _, v := range testCases {
+		parsed := gofall.ParseTypeLine(v.typeLine)
+
+		if !reflect.DeepEqual(parsed.Faces, v.expected) {
+			t.Errorf("unexpected faces for %q: %+v", v.typeLine, parsed.Faces)
+		}
+	}
+}
+
+func Test_TypeLine_Helpers(t *testing.T) {
+	t.Parallel()
+
+	mdfc := gofall.ParseTypeLine("Instant // Land")
+
+	if !mdfc.IsLand() || mdfc.Front().IsLand() {
+		t.Errorf("expected only the back face to be a land")
+	}
+
+	if mdfc.IsCreature() || mdfc.IsLegendary() {
+		t.Errorf("did not expect a creature or legendary")
+	}
+
+	card := gofall.Card{TypeLine: "Legendary Creature — Goblin Warrior"}
+	parsed := card.ParsedTypeLine()
+
+	if !parsed.IsCreature() || !parsed.IsLegendary() || !parsed.HasSubtype("Goblin") || parsed.HasSubtype("Elf") {
+		t.Errorf("unexpected helper results for %q", card.TypeLine)
+	}
+
+	for _, typeLine := range []string{
+		"Legendary Creature — Goblin Warrior",
+		"Sorcery — Adventure // Creature — Human Knight",
+		"Basic Land — Mountain",
+	} {
+		if got := gofall.ParseTypeLine(typeLine).String(); got != typeLine {
+			t.Errorf("expected %q to round trip, got %q", typeLine, got)
+		}
+	}
+}
+
+func Test_TypeLine_Validate(t *testing.T) {
+	t.Parallel()
+
+	catalog := &gofall.TypeCatalog{
+		Supertypes: []string{"Basic", "Legendary"},
+		CardTypes:  []string{"Creature", "Land"},
+		Subtypes:   []string{"Goblin", "Mountain", "Time Lord"},
+	}
+
+	for _, typeLine := range []string{"Legendary Creature — Goblin", "Basic Land — Mountain", "Creature — Time Lord"} {
+		if err := gofall.ParseTypeLine(typeLine).Validate(catalog); err != nil {
+			t.Errorf("unexpected error for %q: %v", typeLine, err)
+		}
+	}
+
+	err := gofall.ParseTypeLine("Snow Creature — Goblin Wizard").Validate(catalog)
+	if !errors.Is(err, gofall.ErrUnknownType) {
+		t.Errorf("expected ErrUnknownType, got %v", err)
+	}
+
+	if err := gofall.ParseTypeLine("Snow Creature — Goblin Wizard").Validate(&gofall.TypeCatalog{}); err != nil {
+		t.Errorf("expected an empty catalog to accept anything, got %v", err)
+	}
+}